@@ -0,0 +1,172 @@
+package env
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// kindTypes maps basic kinds to the type whose parser handles them.
+var kindTypes = map[reflect.Kind]reflect.Type{
+	reflect.String:  reflect.TypeFor[string](),
+	reflect.Bool:    reflect.TypeFor[bool](),
+	reflect.Int:     reflect.TypeFor[int](),
+	reflect.Int8:    reflect.TypeFor[int8](),
+	reflect.Int16:   reflect.TypeFor[int16](),
+	reflect.Int32:   reflect.TypeFor[int32](),
+	reflect.Int64:   reflect.TypeFor[int64](),
+	reflect.Uint:    reflect.TypeFor[uint](),
+	reflect.Uint8:   reflect.TypeFor[uint8](),
+	reflect.Uint16:  reflect.TypeFor[uint16](),
+	reflect.Uint32:  reflect.TypeFor[uint32](),
+	reflect.Uint64:  reflect.TypeFor[uint64](),
+	reflect.Float32: reflect.TypeFor[float32](),
+	reflect.Float64: reflect.TypeFor[float64](),
+}
+
+// Bind populates the fields of the struct pointed to by target from
+// environment variables described by struct tags.
+//
+// Supported tags:
+//
+//	env:"DB_PORT"    the environment variable to read
+//	default:"5432"   the value to use when the variable is not set
+//	required:"true"  fail when the variable is not set and has no default
+//
+// Fields of any type with a registered parser (see RegisterParser) are
+// supported, which includes string, bool, the integer and float types and
+// time.Duration. Types whose pointer implements encoding.TextUnmarshaler
+// or flag.Value are filled through that interface, and other named types
+// fall back to the parser of their underlying kind. Nested structs are
+// bound recursively. Fields without an env tag are left untouched.
+//
+// Values are read and parsed the same way as GetString, GetBoolOrError,
+// GetIntOrError and GetFloat64OrError, so base64: and obfuscated: prefixes
+// are honoured.
+//
+// Parameters:
+//
+//	target: A pointer to the struct to populate.
+//
+// Returns:
+//
+//	An error listing every field that is missing or malformed, or nil.
+func Bind(target any) error {
+	return Default().Bind(target)
+}
+
+// Bind populates the fields of the struct pointed to by target from the
+// reader's source. See the package-level Bind for the supported tags.
+func (r *Reader) Bind(target any) error {
+	value := reflect.ValueOf(target)
+
+	if value.Kind() != reflect.Pointer || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return errors.New("bind target must be a non-nil pointer to a struct")
+	}
+
+	errs := r.bindStruct(value.Elem(), "")
+
+	return errors.Join(errs...)
+}
+
+// bindStruct binds every tagged field of the struct value, returning one
+// error per field that could not be populated.
+func (r *Reader) bindStruct(value reflect.Value, prefix string) []error {
+	var errs []error
+
+	valueType := value.Type()
+
+	for i := 0; i < valueType.NumField(); i++ {
+		field := valueType.Field(i)
+		fieldValue := value.Field(i)
+
+		if !field.IsExported() {
+			continue
+		}
+
+		fieldName := prefix + field.Name
+		key, hasKey := field.Tag.Lookup("env")
+
+		if !hasKey && field.Type.Kind() == reflect.Struct {
+			errs = append(errs, r.bindStruct(fieldValue, fieldName+".")...)
+			continue
+		}
+
+		if !hasKey || key == "" || key == "-" {
+			continue
+		}
+
+		if err := r.bindField(fieldValue, field, key); err != nil {
+			errs = append(errs, fmt.Errorf("field '%s': %w", fieldName, err))
+		}
+	}
+
+	return errs
+}
+
+// bindField reads the variable key and stores the parsed value
+// in fieldValue.
+func (r *Reader) bindField(fieldValue reflect.Value, field reflect.StructField, key string) error {
+	valueStr, ok, err := r.lookup(key)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		defaultValue, hasDefault := field.Tag.Lookup("default")
+		required := strings.EqualFold(strings.TrimSpace(field.Tag.Get("required")), "true")
+
+		switch {
+		case hasDefault:
+			valueStr = envProcess(defaultValue)
+		case required:
+			return notFoundError(key)
+		default:
+			return nil
+		}
+	}
+
+	value, err := r.parseAs(key, valueStr, field.Type)
+	if err != nil {
+		return err
+	}
+
+	fieldValue.Set(value)
+
+	return nil
+}
+
+// parseAs parses valueStr, the value of key, as a value of type typ using
+// the parser registered for typ, encoding.TextUnmarshaler or flag.Value,
+// or the parser of its underlying kind, in that order.
+func (r *Reader) parseAs(key string, valueStr string, typ reflect.Type) (reflect.Value, error) {
+	parse, found := lookupErasedParser(typ)
+
+	if !found && isTextTarget(typ) {
+		target := reflect.New(typ)
+		if err := unmarshalText(key, valueStr, target.Interface()); err != nil {
+			return reflect.Value{}, r.annotate(key, err)
+		}
+		return target.Elem(), nil
+	}
+
+	// Named types such as "type Port int" fall back to the parser of
+	// their underlying kind
+	if !found {
+		if kindType, exists := kindTypes[typ.Kind()]; exists {
+			parse, found = lookupErasedParser(kindType)
+		}
+	}
+
+	if !found {
+		return reflect.Value{}, fmt.Errorf("unsupported type '%s'", typ)
+	}
+
+	value, err := parse(r, key, valueStr)
+	if err != nil {
+		return reflect.Value{}, r.annotate(key, err)
+	}
+
+	return reflect.ValueOf(value).Convert(typ), nil
+}
@@ -0,0 +1,124 @@
+package env
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBind(t *testing.T) {
+	type database struct {
+		Host string `env:"TEST_BIND_DB_HOST" default:"localhost"`
+		Port int    `env:"TEST_BIND_DB_PORT" default:"5432"`
+	}
+
+	type config struct {
+		Name     string        `env:"TEST_BIND_NAME" required:"true"`
+		Debug    bool          `env:"TEST_BIND_DEBUG"`
+		Ratio    float64       `env:"TEST_BIND_RATIO"`
+		Secret   string        `env:"TEST_BIND_SECRET"`
+		Timeout  time.Duration `env:"TEST_BIND_TIMEOUT" default:"30"`
+		Database database
+		Ignored  string
+	}
+
+	os.Setenv("TEST_BIND_NAME", "app")
+	os.Setenv("TEST_BIND_DEBUG", "yes")
+	os.Setenv("TEST_BIND_RATIO", "0.5")
+	os.Setenv("TEST_BIND_SECRET", "base64:c2VjcmV0")
+	os.Setenv("TEST_BIND_DB_PORT", "6543")
+	defer os.Unsetenv("TEST_BIND_NAME")
+	defer os.Unsetenv("TEST_BIND_DEBUG")
+	defer os.Unsetenv("TEST_BIND_RATIO")
+	defer os.Unsetenv("TEST_BIND_SECRET")
+	defer os.Unsetenv("TEST_BIND_DB_PORT")
+
+	cfg := config{Ignored: "untouched"}
+	err := Bind(&cfg)
+	if err != nil {
+		t.Fatalf("Expected nil error, got '%s'", err)
+	}
+
+	if cfg.Name != "app" {
+		t.Errorf("Expected 'app', got '%s'", cfg.Name)
+	}
+	if cfg.Debug != true {
+		t.Errorf("Expected true, got %v", cfg.Debug)
+	}
+	if cfg.Ratio != 0.5 {
+		t.Errorf("Expected 0.5, got %f", cfg.Ratio)
+	}
+	if cfg.Secret != "secret" {
+		t.Errorf("Expected 'secret', got '%s'", cfg.Secret)
+	}
+	if cfg.Timeout != 30*time.Second {
+		t.Errorf("Expected 30s, got %v", cfg.Timeout)
+	}
+	if cfg.Database.Host != "localhost" {
+		t.Errorf("Expected 'localhost', got '%s'", cfg.Database.Host)
+	}
+	if cfg.Database.Port != 6543 {
+		t.Errorf("Expected 6543, got %d", cfg.Database.Port)
+	}
+	if cfg.Ignored != "untouched" {
+		t.Errorf("Expected 'untouched', got '%s'", cfg.Ignored)
+	}
+}
+
+func TestBindAggregatesErrors(t *testing.T) {
+	type config struct {
+		Name  string `env:"TEST_BIND_MISSING" required:"true"`
+		Port  int    `env:"TEST_BIND_BAD_PORT"`
+		Debug bool   `env:"TEST_BIND_BAD_DEBUG" default:"maybe"`
+	}
+
+	os.Setenv("TEST_BIND_BAD_PORT", "54e2")
+	defer os.Unsetenv("TEST_BIND_BAD_PORT")
+
+	cfg := config{}
+	err := Bind(&cfg)
+	if err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+
+	for _, field := range []string{"Name", "Port", "Debug"} {
+		if !strings.Contains(err.Error(), "field '"+field+"'") {
+			t.Errorf("Expected error to mention field '%s', got '%s'", field, err)
+		}
+	}
+}
+
+func TestBindInvalidTarget(t *testing.T) {
+	type config struct{}
+
+	if err := Bind(config{}); err == nil {
+		t.Error("Expected error for non-pointer target, got nil")
+	}
+
+	var cfg *config
+	if err := Bind(cfg); err == nil {
+		t.Error("Expected error for nil pointer target, got nil")
+	}
+}
+
+func TestBindSizedIntegers(t *testing.T) {
+	type config struct {
+		ID   int64  `env:"ID"`
+		Port uint16 `env:"PORT"`
+		Mask uint8  `env:"MASK"`
+	}
+
+	cfg := config{}
+	err := New(MapSource{"ID": "9007199254740993", "PORT": "8080", "MASK": "256"}).Bind(&cfg)
+	if !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("Expected ErrOutOfRange for MASK, got %v", err)
+	}
+	if cfg.ID != 9007199254740993 {
+		t.Errorf("Expected 9007199254740993, got %d", cfg.ID)
+	}
+	if cfg.Port != 8080 {
+		t.Errorf("Expected 8080, got %d", cfg.Port)
+	}
+}
@@ -1,7 +1,6 @@
 package env
 
 import (
-	"fmt"
 	"regexp"
 	"strconv"
 	"strings"
@@ -35,29 +34,58 @@ func init() {
 // GetBool retrieves the boolean value of an environment variable.
 // It returns false if the key is not found or the value is not a valid boolean.
 func GetBool(key string) bool {
-	value, err := GetBoolOrError(key)
-	if err != nil {
-		return false
-	}
-	return value
+	return Default().GetBool(key)
 }
 
 // GetBoolOrDefault retrieves the boolean value of an environment variable with a default.
 func GetBoolOrDefault(key string, defaultValue bool) bool {
-	value, err := GetBoolOrError(key)
-	if err != nil {
-		return defaultValue
-	}
-	return value
+	return Default().GetBoolOrDefault(key, defaultValue)
 }
 
 // GetBoolOrError retrieves the boolean value of an environment variable,
 // returning an error if the key is not found or the value is not a valid boolean.
 func GetBoolOrError(key string) (bool, error) {
-	valueStr := strings.TrimSpace(GetString(key))
-	if valueStr == "" {
-		return false, fmt.Errorf("environment variable '%s' not found", key)
+	return Default().GetBoolOrError(key)
+}
+
+// GetBoolOrPanic retrieves the boolean value of an environment variable,
+// panicking if not set or on parsing error.
+func GetBoolOrPanic(key string) bool {
+	return Default().GetBoolOrPanic(key)
+}
+
+// GetBool retrieves the boolean value of key.
+// It returns false if the key is not found or the value is not a valid boolean.
+func (r *Reader) GetBool(key string) bool {
+	value, err := r.GetBoolOrError(key)
+	return orDefault(r, value, err, false)
+}
+
+// GetBoolOrDefault retrieves the boolean value of key with a default.
+func (r *Reader) GetBoolOrDefault(key string, defaultValue bool) bool {
+	value, err := r.GetBoolOrError(key)
+	return orDefault(r, value, err, defaultValue)
+}
+
+// GetBoolOrError retrieves the boolean value of key,
+// returning an error if the key is not found or the value is not a valid boolean.
+func (r *Reader) GetBoolOrError(key string) (bool, error) {
+	return readValue(r, key, parseBool)
+}
+
+// GetBoolOrPanic retrieves the boolean value of key,
+// panicking if not set or on parsing error.
+func (r *Reader) GetBoolOrPanic(key string) bool {
+	value, err := r.GetBoolOrError(key)
+	if err != nil {
+		panic(err)
 	}
+	return value
+}
+
+// parseBool parses a boolean value read from the environment variable key.
+func parseBool(key string, valueStr string) (bool, error) {
+	valueStr = strings.TrimSpace(valueStr)
 
 	// First, honor the explicit truthy/falsy token lists from constants.go
 	if _, ok := trueSet[valueStr]; ok {
@@ -80,17 +108,7 @@ func GetBoolOrError(key string) (bool, error) {
 
 	value, err := strconv.ParseBool(valueStr)
 	if err != nil {
-		return false, fmt.Errorf("environment variable '%s' with value '%s' cannot be parsed as a boolean", key, valueStr)
+		return false, invalidError(key, valueStr, "boolean", nil)
 	}
 	return value, nil
 }
-
-// GetBoolOrPanic retrieves the boolean value of an environment variable,
-// panicking if not set or on parsing error.
-func GetBoolOrPanic(key string) bool {
-	value, err := GetBoolOrError(key)
-	if err != nil {
-		panic(err)
-	}
-	return value
-}
@@ -0,0 +1,566 @@
+// Command env manages encrypted vaults and .env files in the formats read
+// by env.LoadVault and env.Load.
+//
+// Usage:
+//
+//	env <command> [flags] [arguments]
+//
+// Commands:
+//
+//	init                      create an empty vault
+//	set KEY VALUE             set a key in the vault
+//	get KEY                   print the value of a key
+//	delete KEY                remove a key from the vault
+//	list                      list the keys of the vault
+//	rotate                    re-encrypt the vault with a new password
+//	import FILE...            copy the variables of .env files into the vault
+//	export                    write the vault as a .env file
+//	validate [FILE...]        check that .env files (and a vault) can be read
+//	run -- PROGRAM [ARG...]   run a program with .env files and vaults loaded
+//
+// Vault commands accept -vault (default .env.vault). The password is taken
+// from -password, -password-file or -password-fd, then from the
+// ENV_VAULT_PASSWORD environment variable, and finally prompted for when
+// standard input is a terminal. The new password of rotate is taken from
+// -new-password or -new-password-file, then from ENV_VAULT_NEW_PASSWORD,
+// and finally prompted for.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/dracory/env"
+)
+
+// passwordVariable is the environment variable holding the vault password
+// when -password is not given.
+const passwordVariable = "ENV_VAULT_PASSWORD"
+
+// newPasswordVariable is the environment variable holding the new vault
+// password of the rotate command when no new password flag is given.
+const newPasswordVariable = "ENV_VAULT_NEW_PASSWORD"
+
+// defaultVaultPath is the vault used when -vault is not given.
+const defaultVaultPath = ".env.vault"
+
+// command is a subcommand. It returns a *usageError for invalid arguments.
+type command struct {
+	name    string
+	summary string
+	run     func(args []string, stdout io.Writer, stderr io.Writer) error
+}
+
+// commands lists the subcommands in the order they are shown in the usage.
+var commands = []command{
+	{"init", "create an empty vault", runInit},
+	{"set", "set a key in the vault: set KEY VALUE", runSet},
+	{"get", "print the value of a key: get KEY", runGet},
+	{"delete", "remove a key from the vault: delete KEY", runDelete},
+	{"list", "list the keys of the vault", runList},
+	{"rotate", "re-encrypt the vault with a new password", runRotate},
+	{"import", "copy the variables of .env files into the vault: import FILE...", runImport},
+	{"export", "write the vault as a .env file", runExport},
+	{"validate", "check that .env files and a vault can be read: validate [FILE...]", runValidate},
+	{"run", "run a program with .env files and vaults loaded: run [-env FILE] [-vault FILE] -- PROGRAM [ARG...]", runRun},
+}
+
+// usageError reports invalid command-line arguments.
+type usageError struct {
+	message string
+}
+
+// Error returns the error message.
+func (e *usageError) Error() string {
+	return e.message
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run executes the command line args and returns the exit code: 0 on
+// success, 1 if the command failed and 2 for invalid arguments. The run
+// command returns the exit code of the program it ran.
+func run(args []string, stdout io.Writer, stderr io.Writer) int {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		printUsage(stderr)
+		if len(args) == 0 {
+			return 2
+		}
+		return 0
+	}
+
+	index := slices.IndexFunc(commands, func(c command) bool {
+		return c.name == args[0]
+	})
+	if index < 0 {
+		fmt.Fprintf(stderr, "env: unknown command %q\n", args[0])
+		printUsage(stderr)
+		return 2
+	}
+
+	err := commands[index].run(args[1:], stdout, stderr)
+
+	var usageErr *usageError
+	var exitErr *exitCodeError
+	switch {
+	case err == nil:
+		return 0
+	case errors.Is(err, flag.ErrHelp):
+		return 0
+	case errors.As(err, &exitErr):
+		return exitErr.code
+	case errors.As(err, &usageErr):
+		fmt.Fprintf(stderr, "env %s: %s\n", args[0], usageErr.message)
+		return 2
+	default:
+		fmt.Fprintf(stderr, "env %s: %s\n", args[0], err)
+		return 1
+	}
+}
+
+// printUsage writes the list of commands to w.
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: env <command> [flags] [arguments]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	for _, c := range commands {
+		fmt.Fprintf(w, "  %-10s %s\n", c.name, c.summary)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "Vault commands accept -vault (default %s) and -password, -password-file or -password-fd.\n", defaultVaultPath)
+	fmt.Fprintf(w, "Without them the password is read from $%s or prompted for.\n", passwordVariable)
+}
+
+// vaultFlags holds the flags shared by the vault commands.
+type vaultFlags struct {
+	path         string
+	password     string
+	passwordFile string
+	passwordFD   int
+}
+
+// registerPassword registers the password flags.
+func (v *vaultFlags) registerPassword(flags *flag.FlagSet) {
+	flags.StringVar(&v.password, "password", "", "vault password")
+	flags.StringVar(&v.passwordFile, "password-file", "", "file holding the vault password")
+	flags.IntVar(&v.passwordFD, "password-fd", -1, "inherited file descriptor holding the vault password")
+}
+
+// newFlagSet creates the flag set of a command, with the vault flags
+// registered when vault is not nil.
+func newFlagSet(name string, stderr io.Writer, vault *vaultFlags) *flag.FlagSet {
+	flags := flag.NewFlagSet("env "+name, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+
+	if vault != nil {
+		flags.StringVar(&vault.path, "vault", defaultVaultPath, "path to the vault file")
+		vault.registerPassword(flags)
+	}
+
+	return flags
+}
+
+// parseFlags parses args, requiring exactly arity positional arguments, or
+// at least one if arity is negative.
+func parseFlags(flags *flag.FlagSet, args []string, arity int) error {
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return &usageError{message: err.Error()}
+	}
+
+	switch {
+	case arity < 0 && flags.NArg() == 0:
+		return &usageError{message: "at least one argument is required"}
+	case arity >= 0 && flags.NArg() != arity:
+		return &usageError{message: fmt.Sprintf("expected %d argument(s), got %d", arity, flags.NArg())}
+	}
+
+	return nil
+}
+
+// resolvePassword returns the vault password from the password flags, the
+// environment or a prompt. The result is kept, as the environment variable
+// is unset once read.
+func (v *vaultFlags) resolvePassword() (string, error) {
+	if v.password != "" {
+		return v.password, nil
+	}
+
+	sources := []env.PasswordSource{}
+
+	if v.passwordFile != "" {
+		sources = append(sources, env.PasswordFromFile(v.passwordFile))
+	}
+
+	if v.passwordFD >= 0 {
+		sources = append(sources, env.PasswordFromFD(uintptr(v.passwordFD)))
+	}
+
+	sources = append(sources, env.PasswordFromEnv(passwordVariable), env.PasswordFromPrompt("Vault password: "))
+
+	password, err := env.ResolvePassword(sources...)
+	if err != nil {
+		return "", &usageError{message: err.Error()}
+	}
+
+	v.password = password
+
+	return password, nil
+}
+
+// options returns the vault options for the vault path and password.
+func (v *vaultFlags) options() (env.VaultOptions, error) {
+	password, err := v.resolvePassword()
+	if err != nil {
+		return env.VaultOptions{}, err
+	}
+
+	return env.VaultOptions{Password: password, VaultFilePath: v.path}, nil
+}
+
+// readKeys decrypts the vault and returns its variables.
+func (v *vaultFlags) readKeys() (map[string]string, error) {
+	options, err := v.options()
+	if err != nil {
+		return nil, err
+	}
+
+	return env.VaultList(options)
+}
+
+// keyError reports an invalid key as a usage error.
+func keyError(err error) error {
+	if errors.Is(err, env.ErrInvalid) {
+		return &usageError{message: err.Error()}
+	}
+	return err
+}
+
+// runInit creates an empty vault.
+func runInit(args []string, stdout io.Writer, stderr io.Writer) error {
+	vault := &vaultFlags{}
+	flags := newFlagSet("init", stderr, vault)
+	if err := parseFlags(flags, args, 0); err != nil {
+		return err
+	}
+
+	options, err := vault.options()
+	if err != nil {
+		return err
+	}
+
+	if err := env.VaultInit(options); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(stdout, "Created %s\n", vault.path)
+
+	return nil
+}
+
+// runSet sets a key in the vault.
+func runSet(args []string, stdout io.Writer, stderr io.Writer) error {
+	vault := &vaultFlags{}
+	flags := newFlagSet("set", stderr, vault)
+	if err := parseFlags(flags, args, 2); err != nil {
+		return err
+	}
+
+	options, err := vault.options()
+	if err != nil {
+		return err
+	}
+
+	return keyError(env.VaultSet(options, flags.Arg(0), flags.Arg(1)))
+}
+
+// runGet prints the value of a key.
+func runGet(args []string, stdout io.Writer, stderr io.Writer) error {
+	vault := &vaultFlags{}
+	flags := newFlagSet("get", stderr, vault)
+	if err := parseFlags(flags, args, 1); err != nil {
+		return err
+	}
+
+	keys, err := vault.readKeys()
+	if err != nil {
+		return err
+	}
+
+	value, ok := keys[flags.Arg(0)]
+	if !ok {
+		return fmt.Errorf("key %q not found in %s", flags.Arg(0), vault.path)
+	}
+
+	fmt.Fprintln(stdout, value)
+
+	return nil
+}
+
+// runDelete removes a key from the vault.
+func runDelete(args []string, stdout io.Writer, stderr io.Writer) error {
+	vault := &vaultFlags{}
+	flags := newFlagSet("delete", stderr, vault)
+	if err := parseFlags(flags, args, 1); err != nil {
+		return err
+	}
+
+	options, err := vault.options()
+	if err != nil {
+		return err
+	}
+
+	return keyError(env.VaultDelete(options, flags.Arg(0)))
+}
+
+// runList prints the keys of the vault, one per line in sorted order.
+func runList(args []string, stdout io.Writer, stderr io.Writer) error {
+	vault := &vaultFlags{}
+	flags := newFlagSet("list", stderr, vault)
+	values := flags.Bool("values", false, "print KEY=VALUE lines instead of keys")
+	if err := parseFlags(flags, args, 0); err != nil {
+		return err
+	}
+
+	keys, err := vault.readKeys()
+	if err != nil {
+		return err
+	}
+
+	for _, key := range sortedKeys(keys) {
+		if !*values {
+			fmt.Fprintln(stdout, key)
+			continue
+		}
+
+		line, err := formatLine(key, keys[key])
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(stdout, line)
+	}
+
+	return nil
+}
+
+// runRotate re-encrypts the vault with a new password.
+func runRotate(args []string, stdout io.Writer, stderr io.Writer) error {
+	vault := &vaultFlags{}
+	flags := newFlagSet("rotate", stderr, vault)
+	newPassword := flags.String("new-password", "", "new vault password")
+	newPasswordFile := flags.String("new-password-file", "", "file holding the new vault password")
+	if err := parseFlags(flags, args, 0); err != nil {
+		return err
+	}
+
+	password, err := vault.resolvePassword()
+	if err != nil {
+		return err
+	}
+
+	sources := []env.PasswordSource{}
+
+	if *newPassword != "" {
+		sources = append(sources, func() (string, error) { return *newPassword, nil })
+	}
+
+	if *newPasswordFile != "" {
+		sources = append(sources, env.PasswordFromFile(*newPasswordFile))
+	}
+
+	sources = append(sources, env.PasswordFromEnv(newPasswordVariable), env.PasswordFromPrompt("New vault password: "))
+
+	resolved, err := env.ResolvePassword(sources...)
+	if err != nil {
+		return &usageError{message: "new " + err.Error()}
+	}
+
+	if err := env.RotateVaultPassword(vault.path, password, resolved); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(stdout, "Rotated the password of %s\n", vault.path)
+
+	return nil
+}
+
+// runImport copies the variables of .env files into the vault, creating
+// the vault if it does not exist. Later files win over earlier ones.
+func runImport(args []string, stdout io.Writer, stderr io.Writer) error {
+	vault := &vaultFlags{}
+	flags := newFlagSet("import", stderr, vault)
+	if err := parseFlags(flags, args, -1); err != nil {
+		return err
+	}
+
+	options, err := vault.options()
+	if err != nil {
+		return err
+	}
+
+	merged := map[string]string{}
+
+	for _, path := range flags.Args() {
+		source, err := env.FileSource(path)
+		if err != nil {
+			return err
+		}
+
+		for key, value := range source.(env.MapSource) {
+			merged[key] = value
+		}
+	}
+
+	if err := env.VaultInit(options); err != nil && !errors.Is(err, fs.ErrExist) {
+		return err
+	}
+
+	if err := env.VaultApply(options, merged); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(stdout, "Imported %d keys into %s\n", len(merged), vault.path)
+
+	return nil
+}
+
+// runExport writes the vault as a .env file.
+func runExport(args []string, stdout io.Writer, stderr io.Writer) error {
+	vault := &vaultFlags{}
+	flags := newFlagSet("export", stderr, vault)
+	output := flags.String("output", "", "file to write (default standard output)")
+	if err := parseFlags(flags, args, 0); err != nil {
+		return err
+	}
+
+	keys, err := vault.readKeys()
+	if err != nil {
+		return err
+	}
+
+	var content strings.Builder
+	for _, key := range sortedKeys(keys) {
+		line, err := formatLine(key, keys[key])
+		if err != nil {
+			return err
+		}
+		content.WriteString(line + "\n")
+	}
+
+	if *output == "" {
+		_, err := io.WriteString(stdout, content.String())
+		return err
+	}
+
+	return os.WriteFile(*output, []byte(content.String()), 0o600)
+}
+
+// runValidate checks that .env files (default .env) parse and, when
+// -vault is given, that the vault can be decrypted.
+func runValidate(args []string, stdout io.Writer, stderr io.Writer) error {
+	vault := &vaultFlags{}
+	flags := newFlagSet("validate", stderr, nil)
+	flags.StringVar(&vault.path, "vault", "", "path to a vault file to check")
+	vault.registerPassword(flags)
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return &usageError{message: err.Error()}
+	}
+
+	paths := flags.Args()
+	if len(paths) == 0 && vault.path == "" {
+		paths = []string{".env"}
+	}
+
+	checked, failed := 0, 0
+
+	for _, path := range paths {
+		checked++
+
+		source, err := env.FileSource(path)
+		if err != nil {
+			fmt.Fprintf(stdout, "FAIL %s: %s\n", path, err)
+			failed++
+			continue
+		}
+		fmt.Fprintf(stdout, "ok   %s (%d variables)\n", path, len(source.(env.MapSource)))
+	}
+
+	if vault.path != "" {
+		checked++
+		keys, err := vault.readKeys()
+		var usageErr *usageError
+		if errors.As(err, &usageErr) {
+			return err
+		}
+		if err != nil {
+			fmt.Fprintf(stdout, "FAIL %s: %s\n", vault.path, err)
+			failed++
+		} else {
+			fmt.Fprintf(stdout, "ok   %s (%d variables)\n", vault.path, len(keys))
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d files failed validation", failed, checked)
+	}
+
+	return nil
+}
+
+// formatLine formats a KEY=VALUE line that godotenv reads back unchanged.
+// Values are single-quoted when possible, so that nothing is expanded, and
+// double-quoted with escapes otherwise. godotenv cannot read a quoted value
+// ending with a backslash, so such values are written unquoted, which
+// fails if they also need quoting.
+func formatLine(key string, value string) (string, error) {
+	if strings.HasSuffix(value, `\`) {
+		if value != strings.TrimSpace(value) || strings.ContainsAny(value, "\n\r$#'\"`") {
+			return "", fmt.Errorf("the value of %s cannot be written to a .env file", key)
+		}
+		return key + "=" + value, nil
+	}
+
+	if !strings.ContainsAny(value, "'\r") {
+		return key + "='" + value + "'", nil
+	}
+
+	var escaped strings.Builder
+	for _, c := range value {
+		switch c {
+		case '\n':
+			escaped.WriteString(`\n`)
+		case '\r':
+			escaped.WriteString(`\r`)
+		case '\\', '"', '!', '$', '`':
+			escaped.WriteRune('\\')
+			escaped.WriteRune(c)
+		default:
+			escaped.WriteRune(c)
+		}
+	}
+
+	return key + `="` + escaped.String() + `"`, nil
+}
+
+// sortedKeys returns the keys of values in sorted order.
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
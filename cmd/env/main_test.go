@@ -0,0 +1,323 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dracory/env"
+	"github.com/joho/godotenv"
+)
+
+// runCommand runs the command line and returns its exit code and output.
+func runCommand(args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := run(args, &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestVaultCommands(t *testing.T) {
+	vault := filepath.Join(t.TempDir(), ".env.vault")
+	flags := []string{"-vault", vault, "-password", "secret"}
+
+	if code, _, stderr := runCommand(append([]string{"init"}, flags...)...); code != 0 {
+		t.Fatalf("Expected init to succeed, got %d: %s", code, stderr)
+	}
+
+	if code, _, _ := runCommand(append([]string{"init"}, flags...)...); code != 1 {
+		t.Errorf("Expected init of an existing vault to fail with 1, got %d", code)
+	}
+
+	for _, pair := range [][2]string{{"DB_HOST", "localhost"}, {"DB_PASSWORD", "p@ss'word $HOME"}} {
+		if code, _, stderr := runCommand(append(append([]string{"set"}, flags...), pair[0], pair[1])...); code != 0 {
+			t.Fatalf("Expected set to succeed, got %d: %s", code, stderr)
+		}
+	}
+
+	code, stdout, _ := runCommand(append(append([]string{"get"}, flags...), "DB_HOST")...)
+	if code != 0 || stdout != "localhost\n" {
+		t.Errorf("Expected 'localhost', got %d '%s'", code, stdout)
+	}
+
+	if code, _, _ := runCommand(append(append([]string{"get"}, flags...), "MISSING")...); code != 1 {
+		t.Errorf("Expected get of a missing key to fail with 1, got %d", code)
+	}
+
+	code, stdout, _ = runCommand(append([]string{"list"}, flags...)...)
+	if code != 0 || stdout != "DB_HOST\nDB_PASSWORD\n" {
+		t.Errorf("Expected sorted keys without the vault id, got %d '%s'", code, stdout)
+	}
+
+	code, stdout, _ = runCommand(append([]string{"export"}, flags...)...)
+	if code != 0 {
+		t.Fatalf("Expected export to succeed, got %d", code)
+	}
+
+	exported, err := godotenv.Unmarshal(stdout)
+	if err != nil {
+		t.Fatalf("Expected export to parse, got '%s'", err)
+	}
+	expected := map[string]string{"DB_HOST": "localhost", "DB_PASSWORD": "p@ss'word $HOME"}
+	if !reflect.DeepEqual(exported, expected) {
+		t.Errorf("Expected %v, got %v", expected, exported)
+	}
+
+	if code, _, stderr := runCommand(append(append([]string{"delete"}, flags...), "DB_HOST")...); code != 0 {
+		t.Fatalf("Expected delete to succeed, got %d: %s", code, stderr)
+	}
+
+	code, stdout, _ = runCommand(append([]string{"list"}, flags...)...)
+	if code != 0 || stdout != "DB_PASSWORD\n" {
+		t.Errorf("Expected 'DB_PASSWORD', got %d '%s'", code, stdout)
+	}
+
+	if code, _, _ := runCommand(append(append([]string{"delete"}, flags...), "DB_HOST")...); code != 1 {
+		t.Errorf("Expected delete of a missing key to fail with 1, got %d", code)
+	}
+
+	_, err = env.LoadVaultWithOptions(env.VaultOptions{Password: "secret", VaultFilePath: vault, Override: env.OverrideReplace})
+	defer os.Unsetenv("DB_PASSWORD")
+	if err != nil {
+		t.Fatalf("Expected LoadVault to read the vault, got '%s'", err)
+	}
+	if os.Getenv("DB_PASSWORD") != "p@ss'word $HOME" {
+		t.Errorf("Expected the value set by the command, got '%s'", os.Getenv("DB_PASSWORD"))
+	}
+}
+
+func TestImportCommand(t *testing.T) {
+	dir := t.TempDir()
+	vault := filepath.Join(dir, ".env.vault")
+
+	first := filepath.Join(dir, ".env")
+	second := filepath.Join(dir, ".env.local")
+	os.WriteFile(first, []byte("APP_NAME=app\nDB_PORT=5432\n"), 0o600)
+	os.WriteFile(second, []byte("DB_PORT=6543\n"), 0o600)
+
+	os.Setenv(passwordVariable, "secret")
+	defer os.Unsetenv(passwordVariable)
+
+	code, _, stderr := runCommand("import", "-vault", vault, first, second)
+	if code != 0 {
+		t.Fatalf("Expected import to succeed, got %d: %s", code, stderr)
+	}
+
+	if _, ok := os.LookupEnv(passwordVariable); ok {
+		t.Errorf("Expected %s to be unset after use", passwordVariable)
+	}
+
+	os.Setenv(passwordVariable, "secret")
+
+	code, stdout, _ := runCommand("list", "-vault", vault, "-values")
+	if code != 0 || stdout != "APP_NAME='app'\nDB_PORT='6543'\n" {
+		t.Errorf("Expected imported values with the later file winning, got %d '%s'", code, stdout)
+	}
+}
+
+func TestValidateCommand(t *testing.T) {
+	dir := t.TempDir()
+
+	valid := filepath.Join(dir, "valid.env")
+	invalid := filepath.Join(dir, "invalid.env")
+	os.WriteFile(valid, []byte("A=1\nB=2\n"), 0o600)
+	os.WriteFile(invalid, []byte("A=1\nnot valid\n"), 0o600)
+
+	code, stdout, _ := runCommand("validate", valid)
+	if code != 0 || !strings.Contains(stdout, "ok   "+valid+" (2 variables)") {
+		t.Errorf("Expected valid file to pass, got %d '%s'", code, stdout)
+	}
+
+	code, stdout, _ = runCommand("validate", valid, invalid)
+	if code != 1 || !strings.Contains(stdout, "FAIL "+invalid) || !strings.Contains(stdout, "line 2") {
+		t.Errorf("Expected invalid file to fail on line 2, got %d '%s'", code, stdout)
+	}
+
+	vault := filepath.Join(dir, ".env.vault")
+	runCommand("init", "-vault", vault, "-password", "secret")
+
+	if code, _, _ := runCommand("validate", "-vault", vault, "-password", "secret"); code != 0 {
+		t.Errorf("Expected vault to pass, got %d", code)
+	}
+	if code, _, _ := runCommand("validate", "-vault", vault, "-password", "wrong"); code != 1 {
+		t.Errorf("Expected vault with a wrong password to fail with 1, got %d", code)
+	}
+}
+
+func TestUsage(t *testing.T) {
+	tests := []struct {
+		args []string
+		code int
+	}{
+		{nil, 2},
+		{[]string{"help"}, 0},
+		{[]string{"unknown"}, 2},
+		{[]string{"get"}, 2},
+		{[]string{"set", "-password", "secret", "id", "value"}, 2},
+		{[]string{"list", "-unknown"}, 2},
+	}
+
+	for _, tt := range tests {
+		if code, _, _ := runCommand(tt.args...); code != tt.code {
+			t.Errorf("%v: expected exit code %d, got %d", tt.args, tt.code, code)
+		}
+	}
+}
+
+func TestMissingPassword(t *testing.T) {
+	os.Unsetenv(passwordVariable)
+
+	code, _, stderr := runCommand("list", "-vault", filepath.Join(t.TempDir(), ".env.vault"))
+	if code != 2 || !strings.Contains(stderr, passwordVariable) {
+		t.Errorf("Expected usage error mentioning %s, got %d '%s'", passwordVariable, code, stderr)
+	}
+}
+
+func TestFormatLine(t *testing.T) {
+	values := []string{"plain", "", "it's", "multi\nline", `C:\path\`, "$HOME and `cmd` and \"quotes\"!", "a'b\\c$d"}
+
+	for _, value := range values {
+		line, err := formatLine("KEY", value)
+		if err != nil {
+			t.Errorf("%q: expected value to be formatted, got '%s'", value, err)
+			continue
+		}
+
+		parsed, err := godotenv.Unmarshal(line)
+		if err != nil {
+			t.Errorf("%q: expected line to parse, got '%s'", value, err)
+			continue
+		}
+		if parsed["KEY"] != value {
+			t.Errorf("Expected %q, got %q", value, parsed["KEY"])
+		}
+	}
+
+	if _, err := formatLine("KEY", `it's a path\`); err == nil {
+		t.Error("Expected error for a value that cannot be written, got nil")
+	}
+}
+
+func TestRunCommand(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+
+	dir := t.TempDir()
+
+	path := filepath.Join(dir, ".env")
+	os.WriteFile(path, []byte("TEST_RUN_GREETING=base64:aGVsbG8=\n"), 0o600)
+
+	vault := filepath.Join(dir, ".env.vault")
+	runCommand("init", "-vault", vault, "-password", "secret")
+	runCommand("set", "-vault", vault, "-password", "secret", "TEST_RUN_NAME", "world")
+
+	code, stdout, stderr := runCommand("run", "-env", path, "-vault", vault, "-password", "secret", "--",
+		"sh", "-c", `echo "$TEST_RUN_GREETING $TEST_RUN_NAME"; exit 3`)
+	if code != 3 {
+		t.Errorf("Expected the exit code of the program, got %d: %s", code, stderr)
+	}
+	if stdout != "hello world\n" {
+		t.Errorf("Expected 'hello world', got '%s'", stdout)
+	}
+
+	if _, ok := os.LookupEnv("TEST_RUN_GREETING"); ok {
+		t.Error("Expected TEST_RUN_GREETING not to be set in the parent process")
+	}
+
+	if code, _, _ := runCommand("run", "-env", path); code != 2 {
+		t.Errorf("Expected usage error without a program, got %d", code)
+	}
+
+	code, _, _ = runCommand("run", "--", "sh", "-c", "kill -TERM $$")
+	if code != 128+15 {
+		t.Errorf("Expected 143 for a program killed by SIGTERM, got %d", code)
+	}
+}
+
+func TestPasswordFile(t *testing.T) {
+	dir := t.TempDir()
+	vault := filepath.Join(dir, ".env.vault")
+	password := filepath.Join(dir, "password")
+	os.WriteFile(password, []byte("secret\n"), 0o600)
+
+	if code, _, stderr := runCommand("init", "-vault", vault, "-password-file", password); code != 0 {
+		t.Fatalf("Expected init to succeed, got %d: %s", code, stderr)
+	}
+
+	if code, _, stderr := runCommand("list", "-vault", vault, "-password", "secret"); code != 0 {
+		t.Errorf("Expected the password from the file to open the vault, got %d: %s", code, stderr)
+	}
+}
+
+func TestRotateCommand(t *testing.T) {
+	vault := filepath.Join(t.TempDir(), ".env.vault")
+	runCommand("init", "-vault", vault, "-password", "old")
+	runCommand("set", "-vault", vault, "-password", "old", "KEY", "value")
+
+	if code, _, stderr := runCommand("rotate", "-vault", vault, "-password", "old", "-new-password", "new"); code != 0 {
+		t.Fatalf("Expected rotate to succeed, got %d: %s", code, stderr)
+	}
+
+	if code, _, _ := runCommand("get", "-vault", vault, "-password", "old", "KEY"); code != 1 {
+		t.Errorf("Expected the old password to fail with 1, got %d", code)
+	}
+
+	code, stdout, _ := runCommand("get", "-vault", vault, "-password", "new", "KEY")
+	if code != 0 || stdout != "value\n" {
+		t.Errorf("Expected 'value' with the new password, got %d '%s'", code, stdout)
+	}
+
+	os.Unsetenv(newPasswordVariable)
+	if code, _, _ := runCommand("rotate", "-vault", vault, "-password", "new"); code != 2 {
+		t.Errorf("Expected usage error without a new password, got %d", code)
+	}
+}
+
+func TestRunVaultSelection(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+
+	vault := filepath.Join(t.TempDir(), ".env.vault")
+	runCommand("init", "-vault", vault, "-password", "secret")
+	runCommand("set", "-vault", vault, "-password", "secret", "BILLING_TEST_RUN_KEY", "billing")
+	runCommand("set", "-vault", vault, "-password", "secret", "SHOP_TEST_RUN_KEY", "shop")
+	runCommand("set", "-vault", vault, "-password", "secret", "BILLING_PATH", "/tmp")
+
+	code, stdout, stderr := runCommand("run", "-vault", vault, "-password", "secret", "-key", "BILLING_TEST_*",
+		"-strip-prefix", "BILLING_", "--", "sh", "-c", `echo "$TEST_RUN_KEY|$SHOP_TEST_RUN_KEY"`)
+	if code != 0 || stdout != "billing|\n" {
+		t.Errorf("Expected only the selected key without its prefix, got %d '%s' %s", code, stdout, stderr)
+	}
+
+	code, _, stderr = runCommand("run", "-vault", vault, "-password", "secret", "-strip-prefix", "BILLING_",
+		"-reject-protected", "--", "true")
+	if code != 1 || !strings.Contains(stderr, "PATH") {
+		t.Errorf("Expected a protected variable to be rejected, got %d '%s'", code, stderr)
+	}
+}
+
+func TestRunVaultPrecedence(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+
+	dir := t.TempDir()
+	shared := filepath.Join(dir, "shared.vault")
+	production := filepath.Join(dir, "production.vault")
+
+	for path, value := range map[string]string{shared: "shared", production: "production"} {
+		runCommand("init", "-vault", path, "-password", "secret")
+		runCommand("set", "-vault", path, "-password", "secret", "TEST_RUN_HOST", value)
+	}
+
+	code, stdout, stderr := runCommand("run", "-vault", shared, "-vault", production, "-password", "secret", "--",
+		"sh", "-c", `echo "$TEST_RUN_HOST"`)
+	if code != 0 || stdout != "production\n" {
+		t.Errorf("Expected the later vault to win, got %d '%s' %s", code, stdout, stderr)
+	}
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/dracory/env"
+)
+
+// forwardedSignals are passed on to the child process of the run command.
+var forwardedSignals = []os.Signal{os.Interrupt, syscall.SIGTERM, syscall.SIGHUP, syscall.SIGQUIT}
+
+// exitCodeError makes run exit with the exit code of a child process.
+type exitCodeError struct {
+	code int
+}
+
+// Error returns the error message.
+func (e *exitCodeError) Error() string {
+	return fmt.Sprintf("exit status %d", e.code)
+}
+
+// stringList is a flag that may be repeated.
+type stringList []string
+
+// String returns the values separated by commas.
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+// Set appends a value.
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// runRun runs a program with the variables of .env files and vaults added
+// to its environment, forwarding signals and exiting with its exit code.
+func runRun(args []string, stdout io.Writer, stderr io.Writer) error {
+	var files, vaults, keys stringList
+
+	vault := &vaultFlags{}
+	flags := newFlagSet("run", stderr, nil)
+	flags.Var(&files, "env", "a .env file to load (repeatable)")
+	flags.Var(&vaults, "vault", "a vault file to load (repeatable; later vaults win)")
+	vault.registerPassword(flags)
+	flags.Var(&keys, "key", "a vault key or glob pattern to load, e.g. BILLING_* (repeatable; default all)")
+	stripPrefix := flags.String("strip-prefix", "", "remove a prefix from vault keys, skipping keys without it")
+	addPrefix := flags.String("add-prefix", "", "add a prefix to vault keys")
+	rejectProtected := flags.Bool("reject-protected", false, "fail if a vault would set a protected variable such as PATH or LD_PRELOAD")
+	override := flags.Bool("override", false, "let loaded values replace variables that are already set")
+	if err := parseFlags(flags, args, -1); err != nil {
+		return err
+	}
+
+	options := env.CommandOptions{Files: files}
+
+	if *override {
+		options.Override = env.OverrideReplace
+	}
+
+	if len(vaults) > 0 {
+		password, err := vault.resolvePassword()
+		if err != nil {
+			return err
+		}
+
+		for _, path := range vaults {
+			options.Vaults = append(options.Vaults, env.VaultOptions{
+				Password:        password,
+				VaultFilePath:   path,
+				Keys:            keys,
+				StripPrefix:     *stripPrefix,
+				AddPrefix:       *addPrefix,
+				RejectProtected: *rejectProtected,
+			})
+		}
+	}
+
+	cmd, err := env.Command(options, flags.Arg(0), flags.Args()[1:]...)
+	if err != nil {
+		return err
+	}
+
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	return runChild(cmd)
+}
+
+// runChild starts cmd, forwards signals to it until it exits, and returns
+// an *exitCodeError if it did not exit successfully.
+func runChild(cmd *exec.Cmd) error {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, forwardedSignals...)
+	defer signal.Stop(signals)
+
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+
+	go func() {
+		for {
+			select {
+			case sig := <-signals:
+				_ = cmd.Process.Signal(sig)
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	err := cmd.Wait()
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return &exitCodeError{code: exitCode(exitErr)}
+	}
+
+	return err
+}
+
+// exitCode returns the exit code of a process, using the shell convention
+// of 128 plus the signal number for a process killed by a signal.
+func exitCode(exitErr *exec.ExitError) int {
+	if status, ok := exitErr.Sys().(syscall.WaitStatus); ok && status.Signaled() {
+		return 128 + int(status.Signal())
+	}
+	return exitErr.ExitCode()
+}
@@ -0,0 +1,52 @@
+//go:build unix
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"os"
+	"os/exec"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestRunForwardsSignals(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+
+	reader, writer := io.Pipe()
+	defer reader.Close()
+
+	var stderr bytes.Buffer
+	done := make(chan int, 1)
+
+	go func() {
+		code := run([]string{"run", "--", "sh", "-c", `trap 'exit 7' TERM; echo ready; while :; do sleep 0.05; done`}, writer, &stderr)
+		writer.Close()
+		done <- code
+	}()
+
+	// The program is started, and signals are being forwarded, once it
+	// prints ready
+	line, err := bufio.NewReader(reader).ReadString('\n')
+	if err != nil || line != "ready\n" {
+		t.Fatalf("Expected 'ready', got '%s' (%v)", line, err)
+	}
+
+	if err := syscall.Kill(os.Getpid(), syscall.SIGTERM); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case code := <-done:
+		if code != 7 {
+			t.Errorf("Expected the exit code of the program's TERM handler, got %d: %s", code, stderr.String())
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the program to exit after the signal was forwarded")
+	}
+}
@@ -0,0 +1,126 @@
+package env
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// CommandOptions configures Command and Environ.
+type CommandOptions struct {
+	// Files lists the .env files to load, in order. Unlike Load, every
+	// file must exist.
+	Files []string
+
+	// Vaults lists the vaults to load after the files, lowest precedence
+	// first. They are merged like LoadVaults merges them: a key defined in
+	// several vaults takes the value from the last one, whatever the
+	// override policy. Their Override field is ignored.
+	Vaults []VaultOptions
+
+	// Override controls what happens to variables that are already set in
+	// the parent process, and to variables set by an earlier file or by
+	// the files before the merged vaults. With OverrideKeep (the default)
+	// the parent's value and then the first loaded value win; with
+	// OverrideReplace the last one does.
+	Override OverridePolicy
+}
+
+// Command returns an *exec.Cmd that runs name with args in an environment
+// built from the parent process environment and the files and vaults of
+// options. The parent process environment is not modified.
+//
+// Loaded values are decoded like the Get functions decode them, so
+// base64: and obfuscated: values reach the child decoded.
+//
+// Parameters:
+//
+//	options: The files and vaults to load and the override policy.
+//	name: The program to run.
+//	args: The arguments to pass to the program.
+//
+// Returns:
+//
+//	The command, ready to be started, or an error if a file or vault
+//	cannot be loaded. A base64: or obfuscated: value that cannot be
+//	decoded is an error matching ErrInvalid.
+func Command(options CommandOptions, name string, args ...string) (*exec.Cmd, error) {
+	environ, err := Environ(options)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := exec.Command(name, args...)
+	cmd.Env = environ
+
+	return cmd, nil
+}
+
+// Environ returns the environment that Command gives to the child process,
+// as "KEY=value" strings suitable for exec.Cmd.Env.
+func Environ(options CommandOptions) ([]string, error) {
+	batches := []valueBatch{}
+
+	for _, path := range options.Files {
+		values, err := readEnvFile(path)
+		if err != nil {
+			return nil, err
+		}
+		processed, err := processValues(path, values)
+		if err != nil {
+			return nil, err
+		}
+
+		batches = append(batches, valueBatch{origin: path, values: processed})
+	}
+
+	if len(options.Vaults) > 0 {
+		merged, report, err := mergeVaults(options.Vaults, processValues)
+		if err != nil {
+			return nil, err
+		}
+
+		batches = append(batches, valueBatch{origin: strings.Join(report.Applied, ", "), values: merged})
+	}
+
+	staged, _, err := stageBatches(batches, options.Override, os.LookupEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	environ := []string{}
+
+	for _, entry := range os.Environ() {
+		key, _, _ := strings.Cut(entry, "=")
+		if _, replaced := staged[key]; !replaced {
+			environ = append(environ, entry)
+		}
+	}
+
+	for _, key := range sortedKeys(staged) {
+		environ = append(environ, key+"="+staged[key])
+	}
+
+	return environ, nil
+}
+
+// processValues returns values decoded like the Get functions decode them.
+// A value that cannot be decoded is an error matching ErrInvalid, naming
+// the key and origin but not the value.
+func processValues(origin string, values map[string]string) (map[string]string, error) {
+	processed := make(map[string]string, len(values))
+
+	for _, key := range sortedKeys(values) {
+		value, err := decodeValue(values[key])
+		if err != nil {
+			return nil, &sentinelError{
+				message:  fmt.Sprintf("cannot decode the value of '%s' from %s", key, origin),
+				sentinel: ErrInvalid,
+			}
+		}
+		processed[key] = value
+	}
+
+	return processed, nil
+}
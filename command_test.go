@@ -0,0 +1,136 @@
+package env
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestEnviron(t *testing.T) {
+	dir := t.TempDir()
+
+	first := filepath.Join(dir, ".env")
+	second := filepath.Join(dir, ".env.local")
+	os.WriteFile(first, []byte("TEST_COMMAND_A=from-env\nTEST_COMMAND_B=base64:ZGVjb2RlZA==\nTEST_COMMAND_PARENT=from-file\n"), 0o600)
+	os.WriteFile(second, []byte("TEST_COMMAND_A=from-local\n"), 0o600)
+
+	vault := createTestVault(t, "secret", map[string]string{"TEST_COMMAND_C": "from-vault"})
+
+	os.Setenv("TEST_COMMAND_PARENT", "from-parent")
+	defer os.Unsetenv("TEST_COMMAND_PARENT")
+
+	environ, err := Environ(CommandOptions{
+		Files:  []string{first, second},
+		Vaults: []VaultOptions{{Password: "secret", VaultFilePath: vault}},
+	})
+	if err != nil {
+		t.Fatalf("Expected nil error, got '%s'", err)
+	}
+
+	expected := []string{
+		"TEST_COMMAND_A=from-env",
+		"TEST_COMMAND_B=decoded",
+		"TEST_COMMAND_C=from-vault",
+		"TEST_COMMAND_PARENT=from-parent",
+	}
+	for _, entry := range expected {
+		if !slices.Contains(environ, entry) {
+			t.Errorf("Expected environment to contain '%s'", entry)
+		}
+	}
+
+	for _, key := range []string{"TEST_COMMAND_A", "TEST_COMMAND_B", "TEST_COMMAND_C"} {
+		if _, ok := os.LookupEnv(key); ok {
+			t.Errorf("Expected %s not to be set in the parent process", key)
+		}
+	}
+
+	environ, err = Environ(CommandOptions{Files: []string{first, second}, Override: OverrideReplace})
+	if err != nil {
+		t.Fatalf("Expected nil error, got '%s'", err)
+	}
+
+	for _, entry := range []string{"TEST_COMMAND_A=from-local", "TEST_COMMAND_PARENT=from-file"} {
+		if !slices.Contains(environ, entry) {
+			t.Errorf("Expected environment to contain '%s'", entry)
+		}
+	}
+	if slices.Contains(environ, "TEST_COMMAND_PARENT=from-parent") {
+		t.Error("Expected the parent value to be replaced")
+	}
+}
+
+func TestEnvironMissingFile(t *testing.T) {
+	_, err := Environ(CommandOptions{Files: []string{filepath.Join(t.TempDir(), "missing.env")}})
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestCommand(t *testing.T) {
+	shell, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("sh not available")
+	}
+
+	path := filepath.Join(t.TempDir(), ".env")
+	os.WriteFile(path, []byte("TEST_COMMAND_GREETING=hello\n"), 0o600)
+
+	cmd, err := Command(CommandOptions{Files: []string{path}}, shell, "-c", "echo $TEST_COMMAND_GREETING")
+	if err != nil {
+		t.Fatalf("Expected nil error, got '%s'", err)
+	}
+
+	output, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("Expected command to succeed, got '%s'", err)
+	}
+
+	if strings.TrimSpace(string(output)) != "hello" {
+		t.Errorf("Expected 'hello', got '%s'", output)
+	}
+}
+
+func TestEnvironDecodeError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env")
+	os.WriteFile(path, []byte("TEST_COMMAND_OK=fine\nTEST_COMMAND_BAD=base64:not*base64\n"), 0o600)
+
+	environ, err := Environ(CommandOptions{Files: []string{path}})
+	if !errors.Is(err, ErrInvalid) {
+		t.Fatalf("Expected ErrInvalid, got %v (environment %v)", err, environ)
+	}
+	if !strings.Contains(err.Error(), "TEST_COMMAND_BAD") || strings.Contains(err.Error(), "not*base64") {
+		t.Errorf("Expected the error to name the key without its value, got '%s'", err)
+	}
+
+	if _, err := Command(CommandOptions{Files: []string{path}}, "true"); !errors.Is(err, ErrInvalid) {
+		t.Errorf("Expected ErrInvalid from Command, got %v", err)
+	}
+}
+
+func TestEnvironVaultPrecedence(t *testing.T) {
+	shared := createTestVault(t, "secret", map[string]string{"TEST_COMMAND_HOST": "shared", "TEST_COMMAND_REGION": "eu"})
+	production := createTestVault(t, "secret", map[string]string{"TEST_COMMAND_HOST": "production"})
+
+	vaults := []VaultOptions{
+		{Password: "secret", VaultFilePath: shared},
+		{Password: "secret", VaultFilePath: production},
+	}
+
+	for _, policy := range []OverridePolicy{OverrideKeep, OverrideReplace} {
+		environ, err := Environ(CommandOptions{Vaults: vaults, Override: policy})
+		if err != nil {
+			t.Fatalf("%s: expected nil error, got '%s'", policy, err)
+		}
+
+		for _, entry := range []string{"TEST_COMMAND_HOST=production", "TEST_COMMAND_REGION=eu"} {
+			if !slices.Contains(environ, entry) {
+				t.Errorf("%s: expected environment to contain '%s'", policy, entry)
+			}
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package env
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultDurationUnit is the unit applied to bare numbers by the Duration
+// getters unless a Reader is created with WithDurationUnit.
+const DefaultDurationUnit = time.Second
+
+// Extended duration units, in addition to those accepted by time.ParseDuration
+const (
+	durationDay  = 24 * time.Hour
+	durationWeek = 7 * durationDay
+)
+
+// extendedUnitRe matches a number followed by a day or week unit
+var extendedUnitRe = regexp.MustCompile(`((?:\d+\.?\d*)|(?:\.\d+))([dw])`)
+
+// GetDuration retrieves the time.Duration value of an environment variable.
+// It returns 0 if the key is not found or the value is not a valid duration.
+func GetDuration(key string) time.Duration {
+	return Default().GetDuration(key)
+}
+
+// GetDurationOrDefault retrieves the time.Duration value of an environment variable with a default.
+func GetDurationOrDefault(key string, defaultValue time.Duration) time.Duration {
+	return Default().GetDurationOrDefault(key, defaultValue)
+}
+
+// GetDurationOrError retrieves the time.Duration value of an environment variable,
+// returning an error if the key is not found or the value is not a valid duration.
+func GetDurationOrError(key string) (time.Duration, error) {
+	return Default().GetDurationOrError(key)
+}
+
+// GetDurationOrPanic retrieves the time.Duration value of an environment variable,
+// panicking if not set or on parsing error.
+func GetDurationOrPanic(key string) time.Duration {
+	return Default().GetDurationOrPanic(key)
+}
+
+// GetDuration retrieves the time.Duration value of key.
+// It returns 0 if the key is not found or the value is not a valid duration.
+func (r *Reader) GetDuration(key string) time.Duration {
+	value, err := r.GetDurationOrError(key)
+	return orDefault(r, value, err, 0)
+}
+
+// GetDurationOrDefault retrieves the time.Duration value of key with a default.
+func (r *Reader) GetDurationOrDefault(key string, defaultValue time.Duration) time.Duration {
+	value, err := r.GetDurationOrError(key)
+	return orDefault(r, value, err, defaultValue)
+}
+
+// GetDurationOrError retrieves the time.Duration value of key,
+// returning an error if the key is not found or the value is not a valid duration.
+//
+// Accepted values are Go duration strings ("1h30m", "250ms"), the extended
+// units "d" (24h) and "w" (7d), which may be combined ("1w2d12h"), and bare
+// numbers, which are multiplied by the reader's duration unit.
+func (r *Reader) GetDurationOrError(key string) (time.Duration, error) {
+	return readValue(r, key, func(key string, valueStr string) (time.Duration, error) {
+		return parseDuration(key, valueStr, r.durationUnit)
+	})
+}
+
+// GetDurationOrPanic retrieves the time.Duration value of key,
+// panicking if not set or on parsing error.
+func (r *Reader) GetDurationOrPanic(key string) time.Duration {
+	value, err := r.GetDurationOrError(key)
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
+// parseDuration parses a duration value read from the environment variable
+// key. Bare numbers are multiplied by unit.
+func parseDuration(key string, valueStr string, unit time.Duration) (time.Duration, error) {
+	valueStr = strings.TrimSpace(valueStr)
+
+	invalid := invalidError(key, valueStr, "duration", nil)
+
+	if number, err := strconv.ParseFloat(valueStr, 64); err == nil && numericRe.MatchString(valueStr) {
+		if unit <= 0 {
+			return 0, invalid
+		}
+		// float64(math.MaxInt64) rounds up to 2^63, which does not fit
+		value := number * float64(unit)
+		if value >= math.MaxInt64 || value < math.MinInt64 {
+			return 0, rangeError(key, valueStr, "duration")
+		}
+		return time.Duration(value), nil
+	}
+
+	expanded := extendedUnitRe.ReplaceAllStringFunc(valueStr, func(match string) string {
+		parts := extendedUnitRe.FindStringSubmatch(match)
+		number, _ := strconv.ParseFloat(parts[1], 64)
+
+		hours := number * durationDay.Hours()
+		if parts[2] == "w" {
+			hours = number * durationWeek.Hours()
+		}
+
+		return strconv.FormatFloat(hours, 'f', -1, 64) + "h"
+	})
+
+	value, err := time.ParseDuration(expanded)
+	if err != nil {
+		return 0, invalid
+	}
+	return value, nil
+}
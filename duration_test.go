@@ -0,0 +1,160 @@
+package env
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestGetDuration(t *testing.T) {
+	os.Setenv("TEST_DURATION", "1h30m")
+	value := GetDuration("TEST_DURATION")
+	if value != 90*time.Minute {
+		t.Errorf("Expected 1h30m, got %v", value)
+	}
+	os.Unsetenv("TEST_DURATION")
+
+	value = GetDuration("NON_EXISTENT")
+	if value != 0 {
+		t.Errorf("Expected 0, got %v", value)
+	}
+
+	os.Setenv("TEST_DURATION_INVALID", "abc")
+	value = GetDuration("TEST_DURATION_INVALID")
+	if value != 0 {
+		t.Errorf("Expected 0, got %v", value)
+	}
+	os.Unsetenv("TEST_DURATION_INVALID")
+}
+
+func TestGetDurationOrDefault(t *testing.T) {
+	os.Setenv("TEST_DURATION", "250ms")
+	value := GetDurationOrDefault("TEST_DURATION", time.Second)
+	if value != 250*time.Millisecond {
+		t.Errorf("Expected 250ms, got %v", value)
+	}
+	os.Unsetenv("TEST_DURATION")
+
+	value = GetDurationOrDefault("NON_EXISTENT", time.Second)
+	if value != time.Second {
+		t.Errorf("Expected 1s, got %v", value)
+	}
+}
+
+func TestGetDurationOrError(t *testing.T) {
+	os.Setenv("TEST_DURATION", "30")
+	value, err := GetDurationOrError("TEST_DURATION")
+	if err != nil {
+		t.Errorf("Expected nil error, got '%s'", err)
+	}
+	if value != 30*time.Second {
+		t.Errorf("Expected 30s, got %v", value)
+	}
+	os.Unsetenv("TEST_DURATION")
+
+	_, err = GetDurationOrError("NON_EXISTENT")
+	if err == nil {
+		t.Error("Expected error, got nil")
+	}
+
+	os.Setenv("TEST_DURATION_INVALID", "abc")
+	_, err = GetDurationOrError("TEST_DURATION_INVALID")
+	if err == nil {
+		t.Error("Expected error, got nil")
+	}
+	os.Unsetenv("TEST_DURATION_INVALID")
+}
+
+func TestGetDurationOrPanic(t *testing.T) {
+	os.Setenv("TEST_DURATION", "1s")
+	defer func() {
+		if r := recover(); r != nil {
+			t.Errorf("The code panicked: %v", r)
+		}
+	}()
+	GetDurationOrPanic("TEST_DURATION")
+	os.Unsetenv("TEST_DURATION")
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("The code did not panic")
+		}
+	}()
+	GetDurationOrPanic("NON_EXISTENT")
+}
+
+func TestGetDuration_Formats(t *testing.T) {
+	cases := []struct {
+		val       string
+		expect    time.Duration
+		shouldErr bool
+	}{
+		{"1h", time.Hour, false},
+		{"1.5h", 90 * time.Minute, false},
+		{"-5m", -5 * time.Minute, false},
+		{"1d", 24 * time.Hour, false},
+		{"2w", 14 * 24 * time.Hour, false},
+		{"1w2d12h", (9*24 + 12) * time.Hour, false},
+		{"0.5d", 12 * time.Hour, false},
+		{"45", 45 * time.Second, false},
+		{"1.5", 1500 * time.Millisecond, false},
+		{"  10m  ", 10 * time.Minute, false},
+		{"1x", 0, true},
+		{"d", 0, true},
+		{"1e30", 0, true},
+	}
+
+	const key = "TEST_DURATION_FORMAT"
+	for i, c := range cases {
+		os.Setenv(key, c.val)
+		got, err := GetDurationOrError(key)
+		if c.shouldErr {
+			if err == nil {
+				t.Errorf("case %d (%q): expected error, got nil (value=%v)", i, c.val, got)
+			}
+		} else {
+			if err != nil {
+				t.Errorf("case %d (%q): unexpected error: %v", i, c.val, err)
+			} else if got != c.expect {
+				t.Errorf("case %d (%q): expected %v, got %v", i, c.val, c.expect, got)
+			}
+		}
+	}
+	os.Unsetenv(key)
+}
+
+func TestReaderDurationUnit(t *testing.T) {
+	reader := New(MapSource{"TIMEOUT": "1500", "EXPLICIT": "2s"}, WithDurationUnit(time.Millisecond))
+
+	if value := reader.GetDuration("TIMEOUT"); value != 1500*time.Millisecond {
+		t.Errorf("Expected 1.5s, got %v", value)
+	}
+	if value := reader.GetDuration("EXPLICIT"); value != 2*time.Second {
+		t.Errorf("Expected 2s, got %v", value)
+	}
+}
+
+func TestReaderDurationRange(t *testing.T) {
+	tests := []struct {
+		value string
+		unit  time.Duration
+	}{
+		{"9223372036.854775807", time.Second},
+		{"9223372036854775807", time.Nanosecond},
+		{"9223372036854775808", time.Nanosecond},
+		{"1e30", time.Second},
+	}
+
+	for _, tt := range tests {
+		value, err := New(MapSource{"TIMEOUT": tt.value}, WithDurationUnit(tt.unit)).GetDurationOrError("TIMEOUT")
+		if !errors.Is(err, ErrOutOfRange) {
+			t.Errorf("%s: expected ErrOutOfRange, got %v (value=%v)", tt.value, err, value)
+		}
+	}
+
+	value, err := New(MapSource{"TIMEOUT": "9223372036"}).GetDurationOrError("TIMEOUT")
+	if err != nil || value != 9223372036*time.Second {
+		t.Errorf("Expected 9223372036s, got %v (err=%v)", value, err)
+	}
+}
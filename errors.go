@@ -0,0 +1,155 @@
+package env
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrNotFound is matched by errors for variables that are not set.
+	ErrNotFound = errors.New("environment variable not found")
+
+	// ErrInvalid is matched by errors for values that are set but cannot
+	// be parsed, including values that are out of range.
+	ErrInvalid = errors.New("environment variable value is invalid")
+
+	// ErrOutOfRange is wrapped by errors for numeric values that are valid
+	// numbers but do not fit in the requested type.
+	ErrOutOfRange = errors.New("value out of range")
+)
+
+// redacted replaces secret values in error messages.
+const redacted = "[REDACTED]"
+
+// secretKeyMarkers are substrings of variable names that hold secrets.
+var secretKeyMarkers = []string{
+	"PASSWORD", "PASSWD", "SECRET", "TOKEN", "CREDENTIAL", "PRIVATE", "API_KEY", "APIKEY", "ACCESS_KEY",
+}
+
+// VarError describes a variable that is missing or cannot be parsed.
+//
+// Use errors.Is with ErrNotFound, ErrInvalid or ErrOutOfRange to tell the
+// cases apart, and errors.As to access the fields.
+type VarError struct {
+	// Key is the variable name. Elements of lists and maps are named by
+	// position, e.g. "PORTS[2]".
+	Key string
+
+	// Value is the value that failed to parse, or empty if not found.
+	Value string
+
+	// Type describes the expected type, e.g. "integer" or "duration".
+	Type string
+
+	// Err is ErrNotFound, ErrOutOfRange, ErrInvalid or the error returned
+	// by the parser.
+	Err error
+
+	// Secret hides Value (and the parser's message) in Error(). It is set
+	// for variables whose name looks like a secret (PASSWORD, TOKEN, ...),
+	// whose value is base64: or obfuscated: encoded, or that were
+	// declared secret.
+	Secret bool
+}
+
+// Error returns the error message, with the value redacted if secret.
+func (e *VarError) Error() string {
+	if errors.Is(e.Err, ErrNotFound) {
+		return fmt.Sprintf("environment variable '%s' not found", e.Key)
+	}
+
+	value := e.Value
+	if e.Secret {
+		value = redacted
+	}
+
+	if errors.Is(e.Err, ErrOutOfRange) {
+		message := fmt.Sprintf("environment variable '%s' with value '%s' is out of range for %s", e.Key, value, article(e.Type))
+		if e.Err != ErrOutOfRange {
+			message += ": " + e.Err.Error()
+		}
+		return message
+	}
+
+	message := fmt.Sprintf("environment variable '%s' with value '%s' cannot be parsed as %s", e.Key, value, article(e.Type))
+
+	if e.Err != nil && e.Err != ErrInvalid && !e.Secret {
+		message += ": " + e.Err.Error()
+	}
+
+	return message
+}
+
+// Unwrap returns the underlying error.
+func (e *VarError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether the error matches target. Every VarError that is
+// not a not-found error matches ErrInvalid.
+func (e *VarError) Is(target error) bool {
+	return target == ErrInvalid && !errors.Is(e.Err, ErrNotFound)
+}
+
+// notFoundError returns the error for a variable that is not set.
+func notFoundError(key string) error {
+	return &VarError{Key: key, Err: ErrNotFound}
+}
+
+// invalidError returns the error for a value that cannot be parsed as
+// typeName. cause may be nil.
+func invalidError(key string, value string, typeName string, cause error) error {
+	if cause == nil {
+		cause = ErrInvalid
+	}
+	return &VarError{Key: key, Value: value, Type: typeName, Err: cause}
+}
+
+// rangeError returns the error for a number that does not fit in typeName.
+func rangeError(key string, value string, typeName string) error {
+	return &VarError{Key: key, Value: value, Type: typeName, Err: ErrOutOfRange}
+}
+
+// sentinelError is an error with its own message that matches a sentinel.
+type sentinelError struct {
+	message  string
+	sentinel error
+}
+
+// Error returns the error message.
+func (e *sentinelError) Error() string {
+	return e.message
+}
+
+// Unwrap returns the sentinel.
+func (e *sentinelError) Unwrap() error {
+	return e.sentinel
+}
+
+// markSecret marks err as secret if it is a *VarError.
+func markSecret(err error) {
+	var varErr *VarError
+	if errors.As(err, &varErr) {
+		varErr.Secret = true
+	}
+}
+
+// looksSecret reports whether the variable name suggests a secret value.
+func looksSecret(key string) bool {
+	upper := strings.ToUpper(key)
+	for _, marker := range secretKeyMarkers {
+		if strings.Contains(upper, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// article prefixes name with "a" or "an".
+func article(name string) string {
+	if name != "" && strings.ContainsRune("aeiouAEIOU", rune(name[0])) {
+		return "an " + name
+	}
+	return "a " + name
+}
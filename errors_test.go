@@ -0,0 +1,196 @@
+package env
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestErrorsNotFound(t *testing.T) {
+	os.Unsetenv("TEST_ERRORS_MISSING")
+
+	checks := map[string]error{}
+
+	_, checks["String"] = GetStringOrError("TEST_ERRORS_MISSING")
+	_, checks["Bool"] = GetBoolOrError("TEST_ERRORS_MISSING")
+	_, checks["Int"] = GetIntOrError("TEST_ERRORS_MISSING")
+	_, checks["Float64"] = GetFloat64OrError("TEST_ERRORS_MISSING")
+	_, checks["Duration"] = GetDurationOrError("TEST_ERRORS_MISSING")
+	_, checks["Slice"] = GetStringSliceOrError("TEST_ERRORS_MISSING")
+	_, checks["Map"] = GetStringMapOrError("TEST_ERRORS_MISSING")
+	_, checks["Get"] = GetOrError[uint16]("TEST_ERRORS_MISSING")
+
+	for name, err := range checks {
+		if !errors.Is(err, ErrNotFound) {
+			t.Errorf("%s: expected ErrNotFound, got %v", name, err)
+		}
+		if errors.Is(err, ErrInvalid) {
+			t.Errorf("%s: expected error not to match ErrInvalid, got %v", name, err)
+		}
+	}
+}
+
+func TestErrorsInvalid(t *testing.T) {
+	os.Setenv("TEST_ERRORS_INVALID", "not-a-value")
+	defer os.Unsetenv("TEST_ERRORS_INVALID")
+
+	checks := map[string]error{}
+
+	_, checks["Bool"] = GetBoolOrError("TEST_ERRORS_INVALID")
+	_, checks["Int"] = GetIntOrError("TEST_ERRORS_INVALID")
+	_, checks["Float64"] = GetFloat64OrError("TEST_ERRORS_INVALID")
+	_, checks["Duration"] = GetDurationOrError("TEST_ERRORS_INVALID")
+	_, checks["Slice"] = GetIntSliceOrError("TEST_ERRORS_INVALID")
+	_, checks["Map"] = GetStringMapOrError("TEST_ERRORS_INVALID")
+
+	for name, err := range checks {
+		if !errors.Is(err, ErrInvalid) {
+			t.Errorf("%s: expected ErrInvalid, got %v", name, err)
+		}
+		if errors.Is(err, ErrNotFound) {
+			t.Errorf("%s: expected error not to match ErrNotFound, got %v", name, err)
+		}
+
+		var varErr *VarError
+		if !errors.As(err, &varErr) {
+			t.Errorf("%s: expected *VarError, got %T", name, err)
+		}
+	}
+}
+
+func TestErrorsVarErrorFields(t *testing.T) {
+	_, err := New(MapSource{"PORTS": "80,http"}).GetIntSliceOrError("PORTS")
+
+	var varErr *VarError
+	if !errors.As(err, &varErr) {
+		t.Fatalf("Expected *VarError, got %T", err)
+	}
+
+	if varErr.Key != "PORTS[1]" {
+		t.Errorf("Expected key 'PORTS[1]', got '%s'", varErr.Key)
+	}
+	if varErr.Value != "http" {
+		t.Errorf("Expected value 'http', got '%s'", varErr.Value)
+	}
+	if varErr.Type != "integer" {
+		t.Errorf("Expected type 'integer', got '%s'", varErr.Type)
+	}
+}
+
+func TestErrorsOutOfRange(t *testing.T) {
+	_, err := New(MapSource{"PORT": "70000"}).GetUint16OrError("PORT")
+
+	if !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("Expected ErrOutOfRange, got %v", err)
+	}
+	if !errors.Is(err, ErrInvalid) {
+		t.Errorf("Expected ErrInvalid, got %v", err)
+	}
+}
+
+func TestErrorsRedaction(t *testing.T) {
+	reader := New(MapSource{
+		"DB_PASSWORD": "hunter2",
+		"ENCODED":     "base64:aHVudGVyMg==",
+		"PLAIN":       "hunter2",
+	})
+
+	tests := []struct {
+		key    string
+		redact bool
+	}{
+		{"DB_PASSWORD", true},
+		{"ENCODED", true},
+		{"PLAIN", false},
+	}
+
+	for _, tt := range tests {
+		_, err := reader.GetIntOrError(tt.key)
+		if err == nil {
+			t.Fatalf("%s: expected error, got nil", tt.key)
+		}
+
+		leaked := strings.Contains(err.Error(), "hunter2")
+		if tt.redact && leaked {
+			t.Errorf("%s: expected value to be redacted, got '%s'", tt.key, err)
+		}
+		if tt.redact && !strings.Contains(err.Error(), "[REDACTED]") {
+			t.Errorf("%s: expected '[REDACTED]' in error, got '%s'", tt.key, err)
+		}
+		if !tt.redact && !leaked {
+			t.Errorf("%s: expected value in error, got '%s'", tt.key, err)
+		}
+	}
+}
+
+func TestErrorsRedactionInterpolation(t *testing.T) {
+	reader := New(MapSource{
+		"DB_PASSWORD": "hunter2",
+		"ENCODED":     "base64:aHVudGVyMg==",
+		"PLAIN":       "plain",
+		"DSN_PORT":    "x${DB_PASSWORD}",
+		"NESTED_PORT": "x${INNER}",
+		"INNER":       "${ENCODED}",
+		"DEFAULT":     "x${UNSET:-${DB_PASSWORD}}",
+		"PLAIN_PORT":  "x${PLAIN}",
+	}, WithInterpolation())
+
+	tests := []struct {
+		key    string
+		redact bool
+	}{
+		{"DSN_PORT", true},
+		{"NESTED_PORT", true},
+		{"DEFAULT", true},
+		{"PLAIN_PORT", false},
+	}
+
+	for _, tt := range tests {
+		_, err := reader.GetIntOrError(tt.key)
+		if err == nil {
+			t.Fatalf("%s: expected error, got nil", tt.key)
+		}
+
+		leaked := strings.Contains(err.Error(), "hunter2") || strings.Contains(err.Error(), "plain")
+		if tt.redact && (leaked || !strings.Contains(err.Error(), "[REDACTED]")) {
+			t.Errorf("%s: expected value to be redacted, got '%s'", tt.key, err)
+		}
+		if !tt.redact && !leaked {
+			t.Errorf("%s: expected value in error, got '%s'", tt.key, err)
+		}
+	}
+}
+
+func TestErrorsLoaders(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := readEnvFile(filepath.Join(dir, "missing.env"))
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for a missing file, got %v", err)
+	}
+
+	malformed := filepath.Join(dir, "malformed.env")
+	if err := os.WriteFile(malformed, []byte("TEST_ERRORS_OK=1\nnot valid\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	err = LoadE(malformed)
+	if !errors.Is(err, ErrInvalid) {
+		t.Errorf("Expected ErrInvalid for a malformed file, got %v", err)
+	}
+	if errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected malformed file not to match ErrNotFound, got %v", err)
+	}
+
+	_, err = LoadVaultWithOptions(VaultOptions{Password: "secret", VaultFilePath: filepath.Join(dir, "missing.vault")})
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for a missing vault, got %v", err)
+	}
+
+	_, err = New(MapSource{"A": "${B:?required}"}, WithInterpolation()).GetStringOrError("A")
+	if !errors.Is(err, ErrInvalid) {
+		t.Errorf("Expected ErrInvalid for an interpolation failure, got %v", err)
+	}
+}
@@ -1,52 +1,30 @@
 package env
 
 import (
-	"fmt"
 	"strconv"
 )
 
 // GetFloat64 retrieves the float64 value of an environment variable.
 // It returns 0.0 if the key is not found or the value is not a valid float64.
 func GetFloat64(key string) float64 {
-	value, err := GetFloat64OrError(key)
-	if err != nil {
-		return 0.0
-	}
-	return value
+	return Default().GetFloat64(key)
 }
 
 // GetFloat64OrDefault retrieves the float64 value of an environment variable with a default.
 func GetFloat64OrDefault(key string, defaultValue float64) float64 {
-	value, err := GetFloat64OrError(key)
-	if err != nil {
-		return defaultValue
-	}
-	return value
+	return Default().GetFloat64OrDefault(key, defaultValue)
 }
 
 // GetFloat64OrError retrieves the float64 value of an environment variable,
 // returning an error if the key is not found or the value is not a valid float64.
 func GetFloat64OrError(key string) (float64, error) {
-	valueStr := GetString(key)
-	if valueStr == "" {
-		return 0.0, fmt.Errorf("environment variable '%s' not found", key)
-	}
-
-	value, err := strconv.ParseFloat(valueStr, 64)
-	if err != nil {
-		return 0.0, fmt.Errorf("environment variable '%s' with value '%s' cannot be parsed as a float64", key, valueStr)
-	}
-	return value, nil
+	return Default().GetFloat64OrError(key)
 }
 
 // GetFloat64OrPanic retrieves the float64 value of an environment variable,
 // panicking if not set or on parsing error.
 func GetFloat64OrPanic(key string) float64 {
-	value, err := GetFloat64OrError(key)
-	if err != nil {
-		panic(err)
-	}
-	return value
+	return Default().GetFloat64OrPanic(key)
 }
 
 // The following Float helpers are aliases for the Float64 variants to provide
@@ -55,22 +33,83 @@ func GetFloat64OrPanic(key string) float64 {
 // GetFloat retrieves the float64 value of an environment variable.
 // It returns 0.0 if the key is not found or the value is not a valid float.
 func GetFloat(key string) float64 {
-	return GetFloat64(key)
+	return Default().GetFloat(key)
 }
 
 // GetFloatOrDefault retrieves the float64 value of an environment variable with a default.
 func GetFloatOrDefault(key string, defaultValue float64) float64 {
-	return GetFloat64OrDefault(key, defaultValue)
+	return Default().GetFloatOrDefault(key, defaultValue)
 }
 
 // GetFloatOrError retrieves the float64 value of an environment variable,
 // returning an error if the key is not found or the value is not a valid float.
 func GetFloatOrError(key string) (float64, error) {
-	return GetFloat64OrError(key)
+	return Default().GetFloatOrError(key)
 }
 
 // GetFloatOrPanic retrieves the float64 value of an environment variable,
 // panicking if not set or on parsing error.
 func GetFloatOrPanic(key string) float64 {
-	return GetFloat64OrPanic(key)
+	return Default().GetFloatOrPanic(key)
+}
+
+// GetFloat64 retrieves the float64 value of key.
+// It returns 0.0 if the key is not found or the value is not a valid float64.
+func (r *Reader) GetFloat64(key string) float64 {
+	value, err := r.GetFloat64OrError(key)
+	return orDefault(r, value, err, 0.0)
+}
+
+// GetFloat64OrDefault retrieves the float64 value of key with a default.
+func (r *Reader) GetFloat64OrDefault(key string, defaultValue float64) float64 {
+	value, err := r.GetFloat64OrError(key)
+	return orDefault(r, value, err, defaultValue)
+}
+
+// GetFloat64OrError retrieves the float64 value of key,
+// returning an error if the key is not found or the value is not a valid float64.
+func (r *Reader) GetFloat64OrError(key string) (float64, error) {
+	return readValue(r, key, parseFloat64)
+}
+
+// GetFloat64OrPanic retrieves the float64 value of key,
+// panicking if not set or on parsing error.
+func (r *Reader) GetFloat64OrPanic(key string) float64 {
+	value, err := r.GetFloat64OrError(key)
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
+// GetFloat retrieves the float64 value of key.
+// It returns 0.0 if the key is not found or the value is not a valid float.
+func (r *Reader) GetFloat(key string) float64 {
+	return r.GetFloat64(key)
+}
+
+// GetFloatOrDefault retrieves the float64 value of key with a default.
+func (r *Reader) GetFloatOrDefault(key string, defaultValue float64) float64 {
+	return r.GetFloat64OrDefault(key, defaultValue)
+}
+
+// GetFloatOrError retrieves the float64 value of key,
+// returning an error if the key is not found or the value is not a valid float.
+func (r *Reader) GetFloatOrError(key string) (float64, error) {
+	return r.GetFloat64OrError(key)
+}
+
+// GetFloatOrPanic retrieves the float64 value of key,
+// panicking if not set or on parsing error.
+func (r *Reader) GetFloatOrPanic(key string) float64 {
+	return r.GetFloat64OrPanic(key)
+}
+
+// parseFloat64 parses a float64 value read from the environment variable key.
+func parseFloat64(key string, valueStr string) (float64, error) {
+	value, err := strconv.ParseFloat(valueStr, 64)
+	if err != nil {
+		return 0.0, invalidError(key, valueStr, "float64", nil)
+	}
+	return value, nil
 }
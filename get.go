@@ -0,0 +1,56 @@
+package env
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Get retrieves the value of an environment variable parsed as T using the
+// parser registered for T (see RegisterParser). Types without a parser
+// whose pointer implements encoding.TextUnmarshaler or flag.Value are
+// filled through that interface.
+// It returns the zero value if the key is not found or the value cannot be parsed.
+func Get[T any](key string) T {
+	var zero T
+	value, err := GetOrError[T](key)
+	return orDefault(Default(), value, err, zero)
+}
+
+// GetOrDefault retrieves the value of an environment variable parsed as T with a default.
+func GetOrDefault[T any](key string, defaultValue T) T {
+	value, err := GetOrError[T](key)
+	return orDefault(Default(), value, err, defaultValue)
+}
+
+// GetOrError retrieves the value of an environment variable parsed as T,
+// returning an error if the key is not found, no parser is registered for
+// T, or the value cannot be parsed.
+func GetOrError[T any](key string) (T, error) {
+	var zero T
+
+	r := Default()
+
+	parse, ok := lookupParser[T]()
+	if !ok && !isTextTarget(reflect.TypeFor[T]()) {
+		return zero, fmt.Errorf("environment variable '%s': no parser registered for type %s", key, reflect.TypeFor[T]())
+	}
+
+	return readValue(r, key, func(key string, valueStr string) (T, error) {
+		if !ok {
+			var value T
+			err := unmarshalText(key, valueStr, &value)
+			return value, err
+		}
+		return parse(r, key, valueStr)
+	})
+}
+
+// GetOrPanic retrieves the value of an environment variable parsed as T,
+// panicking if not set or on parsing error.
+func GetOrPanic[T any](key string) T {
+	value, err := GetOrError[T](key)
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
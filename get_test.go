@@ -0,0 +1,84 @@
+package env
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestGet(t *testing.T) {
+	os.Setenv("TEST_GENERIC_INT", "123")
+	value := Get[int]("TEST_GENERIC_INT")
+	if value != 123 {
+		t.Errorf("Expected 123, got %d", value)
+	}
+	os.Unsetenv("TEST_GENERIC_INT")
+
+	value = Get[int]("NON_EXISTENT")
+	if value != 0 {
+		t.Errorf("Expected 0, got %d", value)
+	}
+}
+
+func TestGetOrDefault(t *testing.T) {
+	os.Setenv("TEST_GENERIC_DURATION", "2m")
+	value := GetOrDefault("TEST_GENERIC_DURATION", time.Second)
+	if value != 2*time.Minute {
+		t.Errorf("Expected 2m, got %v", value)
+	}
+	os.Unsetenv("TEST_GENERIC_DURATION")
+
+	value = GetOrDefault("NON_EXISTENT", time.Second)
+	if value != time.Second {
+		t.Errorf("Expected 1s, got %v", value)
+	}
+}
+
+func TestGetOrError(t *testing.T) {
+	os.Setenv("TEST_GENERIC_UINT16", "8080")
+	os.Setenv("TEST_GENERIC_FLOAT32", "1.5")
+	os.Setenv("TEST_GENERIC_INVALID", "abc")
+	defer os.Unsetenv("TEST_GENERIC_UINT16")
+	defer os.Unsetenv("TEST_GENERIC_FLOAT32")
+	defer os.Unsetenv("TEST_GENERIC_INVALID")
+
+	port, err := GetOrError[uint16]("TEST_GENERIC_UINT16")
+	if err != nil || port != 8080 {
+		t.Errorf("Expected 8080, got %d (%v)", port, err)
+	}
+
+	ratio, err := GetOrError[float32]("TEST_GENERIC_FLOAT32")
+	if err != nil || ratio != 1.5 {
+		t.Errorf("Expected 1.5, got %f (%v)", ratio, err)
+	}
+
+	if _, err := GetOrError[bool]("TEST_GENERIC_INVALID"); err == nil {
+		t.Error("Expected error, got nil")
+	}
+
+	if _, err := GetOrError[string]("NON_EXISTENT"); err == nil {
+		t.Error("Expected error, got nil")
+	}
+
+	if _, err := GetOrError[complex64]("TEST_GENERIC_UINT16"); err == nil {
+		t.Error("Expected error for type without parser, got nil")
+	}
+}
+
+func TestGetOrPanic(t *testing.T) {
+	os.Setenv("TEST_GENERIC_STRING", "hello")
+	defer func() {
+		if r := recover(); r != nil {
+			t.Errorf("The code panicked: %v", r)
+		}
+	}()
+	GetOrPanic[string]("TEST_GENERIC_STRING")
+	os.Unsetenv("TEST_GENERIC_STRING")
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("The code did not panic")
+		}
+	}()
+	GetOrPanic[string]("NON_EXISTENT")
+}
@@ -5,6 +5,7 @@ go 1.24.5
 require (
 	github.com/dracory/envenc v1.1.0
 	github.com/joho/godotenv v1.5.1
+	golang.org/x/term v0.35.0
 )
 
 require (
@@ -22,6 +23,5 @@ require (
 	github.com/samber/lo v1.51.0 // indirect
 	golang.org/x/net v0.44.0 // indirect
 	golang.org/x/sys v0.36.0 // indirect
-	golang.org/x/term v0.35.0 // indirect
 	golang.org/x/text v0.29.0 // indirect
 )
@@ -1,50 +1,71 @@
 package env
 
 import (
-	"fmt"
+	"errors"
 	"strconv"
 )
 
 // GetInt retrieves the integer value of an environment variable.
 // It returns 0 if the key is not found or the value is not a valid integer.
 func GetInt(key string) int {
-	value, err := GetIntOrError(key)
-	if err != nil {
-		return 0
-	}
-	return value
+	return Default().GetInt(key)
 }
 
 // GetIntOrDefault retrieves the integer value of an environment variable with a default.
 func GetIntOrDefault(key string, defaultValue int) int {
-	value, err := GetIntOrError(key)
-	if err != nil {
-		return defaultValue
-	}
-	return value
+	return Default().GetIntOrDefault(key, defaultValue)
 }
 
 // GetIntOrError retrieves the integer value of an environment variable,
 // returning an error if the key is not found or the value is not a valid integer.
 func GetIntOrError(key string) (int, error) {
-	valueStr := GetString(key)
-	if valueStr == "" {
-		return 0, fmt.Errorf("environment variable '%s' not found", key)
-	}
-
-	value, err := strconv.Atoi(valueStr)
-	if err != nil {
-		return 0, fmt.Errorf("environment variable '%s' with value '%s' cannot be parsed as an integer", key, valueStr)
-	}
-	return value, nil
+	return Default().GetIntOrError(key)
 }
 
 // GetIntOrPanic retrieves the integer value of an environment variable,
 // panicking if not set or on parsing error.
 func GetIntOrPanic(key string) int {
-	value, err := GetIntOrError(key)
+	return Default().GetIntOrPanic(key)
+}
+
+// GetInt retrieves the integer value of key.
+// It returns 0 if the key is not found or the value is not a valid integer.
+func (r *Reader) GetInt(key string) int {
+	value, err := r.GetIntOrError(key)
+	return orDefault(r, value, err, 0)
+}
+
+// GetIntOrDefault retrieves the integer value of key with a default.
+func (r *Reader) GetIntOrDefault(key string, defaultValue int) int {
+	value, err := r.GetIntOrError(key)
+	return orDefault(r, value, err, defaultValue)
+}
+
+// GetIntOrError retrieves the integer value of key,
+// returning an error if the key is not found or the value is not a valid integer.
+// A value that does not fit in an int produces an error wrapping ErrOutOfRange.
+func (r *Reader) GetIntOrError(key string) (int, error) {
+	return readValue(r, key, parseInt)
+}
+
+// GetIntOrPanic retrieves the integer value of key,
+// panicking if not set or on parsing error.
+func (r *Reader) GetIntOrPanic(key string) int {
+	value, err := r.GetIntOrError(key)
 	if err != nil {
 		panic(err)
 	}
 	return value
 }
+
+// parseInt parses an integer value read from the environment variable key.
+func parseInt(key string, valueStr string) (int, error) {
+	value, err := strconv.Atoi(valueStr)
+	if errors.Is(err, strconv.ErrRange) {
+		return 0, rangeError(key, valueStr, "integer")
+	}
+	if err != nil {
+		return 0, invalidError(key, valueStr, "integer", nil)
+	}
+	return value, nil
+}
@@ -0,0 +1,256 @@
+package env
+
+import (
+	"errors"
+	"strconv"
+)
+
+// GetInt8 retrieves the int8 value of an environment variable.
+// It returns 0 if the key is not found or the value is not a valid int8.
+func GetInt8(key string) int8 {
+	return Default().GetInt8(key)
+}
+
+// GetInt8OrDefault retrieves the int8 value of an environment variable with a default.
+func GetInt8OrDefault(key string, defaultValue int8) int8 {
+	return Default().GetInt8OrDefault(key, defaultValue)
+}
+
+// GetInt8OrError retrieves the int8 value of an environment variable,
+// returning an error if the key is not found or the value is not a valid int8.
+func GetInt8OrError(key string) (int8, error) {
+	return Default().GetInt8OrError(key)
+}
+
+// GetInt8OrPanic retrieves the int8 value of an environment variable,
+// panicking if not set or on parsing error.
+func GetInt8OrPanic(key string) int8 {
+	return Default().GetInt8OrPanic(key)
+}
+
+// GetInt16 retrieves the int16 value of an environment variable.
+// It returns 0 if the key is not found or the value is not a valid int16.
+func GetInt16(key string) int16 {
+	return Default().GetInt16(key)
+}
+
+// GetInt16OrDefault retrieves the int16 value of an environment variable with a default.
+func GetInt16OrDefault(key string, defaultValue int16) int16 {
+	return Default().GetInt16OrDefault(key, defaultValue)
+}
+
+// GetInt16OrError retrieves the int16 value of an environment variable,
+// returning an error if the key is not found or the value is not a valid int16.
+func GetInt16OrError(key string) (int16, error) {
+	return Default().GetInt16OrError(key)
+}
+
+// GetInt16OrPanic retrieves the int16 value of an environment variable,
+// panicking if not set or on parsing error.
+func GetInt16OrPanic(key string) int16 {
+	return Default().GetInt16OrPanic(key)
+}
+
+// GetInt32 retrieves the int32 value of an environment variable.
+// It returns 0 if the key is not found or the value is not a valid int32.
+func GetInt32(key string) int32 {
+	return Default().GetInt32(key)
+}
+
+// GetInt32OrDefault retrieves the int32 value of an environment variable with a default.
+func GetInt32OrDefault(key string, defaultValue int32) int32 {
+	return Default().GetInt32OrDefault(key, defaultValue)
+}
+
+// GetInt32OrError retrieves the int32 value of an environment variable,
+// returning an error if the key is not found or the value is not a valid int32.
+func GetInt32OrError(key string) (int32, error) {
+	return Default().GetInt32OrError(key)
+}
+
+// GetInt32OrPanic retrieves the int32 value of an environment variable,
+// panicking if not set or on parsing error.
+func GetInt32OrPanic(key string) int32 {
+	return Default().GetInt32OrPanic(key)
+}
+
+// GetInt64 retrieves the int64 value of an environment variable.
+// It returns 0 if the key is not found or the value is not a valid int64.
+func GetInt64(key string) int64 {
+	return Default().GetInt64(key)
+}
+
+// GetInt64OrDefault retrieves the int64 value of an environment variable with a default.
+func GetInt64OrDefault(key string, defaultValue int64) int64 {
+	return Default().GetInt64OrDefault(key, defaultValue)
+}
+
+// GetInt64OrError retrieves the int64 value of an environment variable,
+// returning an error if the key is not found or the value is not a valid int64.
+func GetInt64OrError(key string) (int64, error) {
+	return Default().GetInt64OrError(key)
+}
+
+// GetInt64OrPanic retrieves the int64 value of an environment variable,
+// panicking if not set or on parsing error.
+func GetInt64OrPanic(key string) int64 {
+	return Default().GetInt64OrPanic(key)
+}
+
+// GetInt8 retrieves the int8 value of key.
+// It returns 0 if the key is not found or the value is not a valid int8.
+func (r *Reader) GetInt8(key string) int8 {
+	value, err := r.GetInt8OrError(key)
+	return orDefault(r, value, err, 0)
+}
+
+// GetInt8OrDefault retrieves the int8 value of key with a default.
+func (r *Reader) GetInt8OrDefault(key string, defaultValue int8) int8 {
+	value, err := r.GetInt8OrError(key)
+	return orDefault(r, value, err, defaultValue)
+}
+
+// GetInt8OrError retrieves the int8 value of key,
+// returning an error if the key is not found or the value is not a valid int8.
+// A value that does not fit in int8 produces an error wrapping ErrOutOfRange.
+func (r *Reader) GetInt8OrError(key string) (int8, error) {
+	return readValue(r, key, parseInt8)
+}
+
+// GetInt8OrPanic retrieves the int8 value of key,
+// panicking if not set or on parsing error.
+func (r *Reader) GetInt8OrPanic(key string) int8 {
+	value, err := r.GetInt8OrError(key)
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
+// GetInt16 retrieves the int16 value of key.
+// It returns 0 if the key is not found or the value is not a valid int16.
+func (r *Reader) GetInt16(key string) int16 {
+	value, err := r.GetInt16OrError(key)
+	return orDefault(r, value, err, 0)
+}
+
+// GetInt16OrDefault retrieves the int16 value of key with a default.
+func (r *Reader) GetInt16OrDefault(key string, defaultValue int16) int16 {
+	value, err := r.GetInt16OrError(key)
+	return orDefault(r, value, err, defaultValue)
+}
+
+// GetInt16OrError retrieves the int16 value of key,
+// returning an error if the key is not found or the value is not a valid int16.
+// A value that does not fit in int16 produces an error wrapping ErrOutOfRange.
+func (r *Reader) GetInt16OrError(key string) (int16, error) {
+	return readValue(r, key, parseInt16)
+}
+
+// GetInt16OrPanic retrieves the int16 value of key,
+// panicking if not set or on parsing error.
+func (r *Reader) GetInt16OrPanic(key string) int16 {
+	value, err := r.GetInt16OrError(key)
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
+// GetInt32 retrieves the int32 value of key.
+// It returns 0 if the key is not found or the value is not a valid int32.
+func (r *Reader) GetInt32(key string) int32 {
+	value, err := r.GetInt32OrError(key)
+	return orDefault(r, value, err, 0)
+}
+
+// GetInt32OrDefault retrieves the int32 value of key with a default.
+func (r *Reader) GetInt32OrDefault(key string, defaultValue int32) int32 {
+	value, err := r.GetInt32OrError(key)
+	return orDefault(r, value, err, defaultValue)
+}
+
+// GetInt32OrError retrieves the int32 value of key,
+// returning an error if the key is not found or the value is not a valid int32.
+// A value that does not fit in int32 produces an error wrapping ErrOutOfRange.
+func (r *Reader) GetInt32OrError(key string) (int32, error) {
+	return readValue(r, key, parseInt32)
+}
+
+// GetInt32OrPanic retrieves the int32 value of key,
+// panicking if not set or on parsing error.
+func (r *Reader) GetInt32OrPanic(key string) int32 {
+	value, err := r.GetInt32OrError(key)
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
+// GetInt64 retrieves the int64 value of key.
+// It returns 0 if the key is not found or the value is not a valid int64.
+func (r *Reader) GetInt64(key string) int64 {
+	value, err := r.GetInt64OrError(key)
+	return orDefault(r, value, err, 0)
+}
+
+// GetInt64OrDefault retrieves the int64 value of key with a default.
+func (r *Reader) GetInt64OrDefault(key string, defaultValue int64) int64 {
+	value, err := r.GetInt64OrError(key)
+	return orDefault(r, value, err, defaultValue)
+}
+
+// GetInt64OrError retrieves the int64 value of key,
+// returning an error if the key is not found or the value is not a valid int64.
+// A value that does not fit in int64 produces an error wrapping ErrOutOfRange.
+func (r *Reader) GetInt64OrError(key string) (int64, error) {
+	return readValue(r, key, parseInt64)
+}
+
+// GetInt64OrPanic retrieves the int64 value of key,
+// panicking if not set or on parsing error.
+func (r *Reader) GetInt64OrPanic(key string) int64 {
+	value, err := r.GetInt64OrError(key)
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
+// parseInt8 parses a int8 value read from the environment variable key.
+func parseInt8(key string, valueStr string) (int8, error) {
+	return parseSigned[int8](key, valueStr, 8, "int8")
+}
+
+// parseInt16 parses a int16 value read from the environment variable key.
+func parseInt16(key string, valueStr string) (int16, error) {
+	return parseSigned[int16](key, valueStr, 16, "int16")
+}
+
+// parseInt32 parses a int32 value read from the environment variable key.
+func parseInt32(key string, valueStr string) (int32, error) {
+	return parseSigned[int32](key, valueStr, 32, "int32")
+}
+
+// parseInt64 parses a int64 value read from the environment variable key.
+func parseInt64(key string, valueStr string) (int64, error) {
+	return parseSigned[int64](key, valueStr, 64, "int64")
+}
+
+// parseSigned parses a signed integer of the given bit size, reporting
+// values that do not fit as ErrOutOfRange.
+func parseSigned[T int | int8 | int16 | int32 | int64](key string, valueStr string, bitSize int, typeName string) (T, error) {
+	value, err := strconv.ParseInt(valueStr, 10, bitSize)
+	if err != nil {
+		return 0, integerError(key, valueStr, typeName, err)
+	}
+	return T(value), nil
+}
+
+// integerError converts a strconv error into the package error for key.
+func integerError(key string, valueStr string, typeName string, err error) error {
+	if errors.Is(err, strconv.ErrRange) {
+		return rangeError(key, valueStr, typeName)
+	}
+	return invalidError(key, valueStr, typeName, nil)
+}
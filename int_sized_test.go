@@ -0,0 +1,101 @@
+package env
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestGetInt64(t *testing.T) {
+	os.Setenv("TEST_INT64", "9007199254740993")
+	value := GetInt64("TEST_INT64")
+	if value != 9007199254740993 {
+		t.Errorf("Expected 9007199254740993, got %d", value)
+	}
+	os.Unsetenv("TEST_INT64")
+
+	value = GetInt64("NON_EXISTENT")
+	if value != 0 {
+		t.Errorf("Expected 0, got %d", value)
+	}
+}
+
+func TestGetInt8OrDefault(t *testing.T) {
+	os.Setenv("TEST_INT8", "-128")
+	value := GetInt8OrDefault("TEST_INT8", 1)
+	if value != -128 {
+		t.Errorf("Expected -128, got %d", value)
+	}
+	os.Unsetenv("TEST_INT8")
+
+	value = GetInt8OrDefault("NON_EXISTENT", 1)
+	if value != 1 {
+		t.Errorf("Expected 1, got %d", value)
+	}
+}
+
+func TestGetSignedOrError(t *testing.T) {
+	reader := New(MapSource{
+		"INT8_MAX":      "127",
+		"INT8_OVER":     "128",
+		"INT16_MIN":     "-32768",
+		"INT16_UNDER":   "-32769",
+		"INT32_MAX":     "2147483647",
+		"INT32_OVER":    "2147483648",
+		"INT64_OVER":    "9223372036854775808",
+		"INT_OVER":      "99999999999999999999",
+		"NOT_A_NUMBER":  "abc",
+		"FLOAT_INVALID": "1.5",
+	})
+
+	if value, err := reader.GetInt8OrError("INT8_MAX"); err != nil || value != 127 {
+		t.Errorf("Expected 127, got %d (%v)", value, err)
+	}
+	if value, err := reader.GetInt16OrError("INT16_MIN"); err != nil || value != -32768 {
+		t.Errorf("Expected -32768, got %d (%v)", value, err)
+	}
+	if value, err := reader.GetInt32OrError("INT32_MAX"); err != nil || value != 2147483647 {
+		t.Errorf("Expected 2147483647, got %d (%v)", value, err)
+	}
+
+	overflows := []func() error{
+		func() error { _, err := reader.GetInt8OrError("INT8_OVER"); return err },
+		func() error { _, err := reader.GetInt16OrError("INT16_UNDER"); return err },
+		func() error { _, err := reader.GetInt32OrError("INT32_OVER"); return err },
+		func() error { _, err := reader.GetInt64OrError("INT64_OVER"); return err },
+		func() error { _, err := reader.GetIntOrError("INT_OVER"); return err },
+	}
+	for i, overflow := range overflows {
+		if err := overflow(); !errors.Is(err, ErrOutOfRange) {
+			t.Errorf("case %d: expected ErrOutOfRange, got %v", i, err)
+		}
+	}
+
+	for _, key := range []string{"NOT_A_NUMBER", "FLOAT_INVALID", "NON_EXISTENT"} {
+		_, err := reader.GetInt64OrError(key)
+		if err == nil {
+			t.Errorf("%s: expected error, got nil", key)
+		}
+		if errors.Is(err, ErrOutOfRange) {
+			t.Errorf("%s: expected a non-range error, got %v", key, err)
+		}
+	}
+}
+
+func TestGetInt32OrPanic(t *testing.T) {
+	os.Setenv("TEST_INT32", "123")
+	defer func() {
+		if r := recover(); r != nil {
+			t.Errorf("The code panicked: %v", r)
+		}
+	}()
+	GetInt32OrPanic("TEST_INT32")
+	os.Unsetenv("TEST_INT32")
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("The code did not panic")
+		}
+	}()
+	GetInt32OrPanic("NON_EXISTENT")
+}
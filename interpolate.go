@@ -0,0 +1,191 @@
+package env
+
+import (
+	"fmt"
+	"strings"
+)
+
+// InterpolationError reports a value that could not be expanded.
+type InterpolationError struct {
+	// Chain lists the keys being expanded when the error occurred, from
+	// the key that was read to the one that failed.
+	Chain []string
+
+	// Message describes the failure. It never includes the value being
+	// expanded, which may be a secret, except for the message of a
+	// ${VAR:?message} reference.
+	Message string
+}
+
+// Error returns the error message, naming the chain of keys involved.
+func (e *InterpolationError) Error() string {
+	if len(e.Chain) == 0 {
+		return "interpolation failed: " + e.Message
+	}
+	return fmt.Sprintf("interpolation of %s failed: %s", strings.Join(e.Chain, " -> "), e.Message)
+}
+
+// Is reports whether the error matches target. Interpolation errors
+// match ErrInvalid.
+func (e *InterpolationError) Is(target error) bool {
+	return target == ErrInvalid
+}
+
+// Expand expands references to environment variables in value using the
+// default Reader. See (*Reader).Expand.
+func Expand(value string) (string, error) {
+	return Default().Expand(value)
+}
+
+// Expand expands references to variables of the reader's source in value.
+//
+// Supported forms:
+//
+//	${VAR}           the value of VAR, or empty if VAR is not set
+//	${VAR:-default}  the value of VAR, or default if VAR is not set or empty
+//	${VAR:?message}  the value of VAR, or an error with message if VAR is not set or empty
+//	$$               a literal $
+//
+// Referenced values are themselves expanded and processed (base64:,
+// obfuscated:). A reference cycle is reported as an *InterpolationError
+// naming the keys involved.
+func (r *Reader) Expand(value string) (string, error) {
+	return r.expand(value, nil)
+}
+
+// resolve returns the processed value of key, expanding references when
+// interpolation is enabled. chain holds the keys already being resolved.
+func (r *Reader) resolve(key string, chain []string) (string, bool, error) {
+	raw, ok := r.source.Lookup(key)
+	if !ok || (raw == "" && !r.emptyAsSet) {
+		return "", false, nil
+	}
+
+	if !r.interpolate {
+		return envProcess(raw), true, nil
+	}
+
+	for _, seen := range chain {
+		if seen == key {
+			return "", true, &InterpolationError{
+				Chain:   append(append([]string{}, chain...), key),
+				Message: "reference cycle detected",
+			}
+		}
+	}
+
+	expanded, err := r.expand(raw, append(append([]string{}, chain...), key))
+	if err != nil {
+		return "", true, err
+	}
+
+	return envProcess(expanded), true, nil
+}
+
+// expand expands every reference in value. chain holds the keys already
+// being resolved, used for cycle detection and error messages.
+func (r *Reader) expand(value string, chain []string) (string, error) {
+	if !strings.Contains(value, "$") {
+		return value, nil
+	}
+
+	var result strings.Builder
+
+	for i := 0; i < len(value); i++ {
+		if value[i] != '$' || i+1 >= len(value) {
+			result.WriteByte(value[i])
+			continue
+		}
+
+		switch value[i+1] {
+		case '$':
+			result.WriteByte('$')
+			i++
+		case '{':
+			end := matchingBrace(value, i+1)
+			if end < 0 {
+				return "", &InterpolationError{Chain: chain, Message: "unterminated reference"}
+			}
+
+			expanded, err := r.expandReference(value[i+2:end], chain)
+			if err != nil {
+				return "", err
+			}
+
+			result.WriteString(expanded)
+			i = end
+		default:
+			result.WriteByte('$')
+		}
+	}
+
+	return result.String(), nil
+}
+
+// expandReference expands the body of a ${...} reference.
+func (r *Reader) expandReference(body string, chain []string) (string, error) {
+	name, operator, operand := body, "", ""
+
+	if index := strings.Index(body, ":"); index >= 0 {
+		name = body[:index]
+		rest := body[index+1:]
+
+		if rest == "" || (rest[0] != '-' && rest[0] != '?') {
+			return "", &InterpolationError{Chain: chain, Message: fmt.Sprintf("unsupported operator in reference to '%s'", name)}
+		}
+
+		operator, operand = rest[:1], rest[1:]
+	}
+
+	if name == "" {
+		return "", &InterpolationError{Chain: chain, Message: "empty variable name in reference"}
+	}
+
+	value, ok, err := r.resolve(name, chain)
+	if err != nil {
+		return "", err
+	}
+
+	if ok && value != "" {
+		return value, nil
+	}
+
+	switch operator {
+	case "-":
+		return r.expand(operand, chain)
+	case "?":
+		message, err := r.expand(operand, chain)
+		if err != nil {
+			return "", err
+		}
+		if message == "" {
+			message = "not set"
+		}
+		return "", &InterpolationError{
+			Chain:   append(append([]string{}, chain...), name),
+			Message: message,
+		}
+	}
+
+	return "", nil
+}
+
+// matchingBrace returns the index of the brace closing the one at open,
+// accounting for nested ${...} references, or -1 if there is none.
+func matchingBrace(value string, open int) int {
+	depth := 0
+
+	for i := open; i < len(value); i++ {
+		switch value[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
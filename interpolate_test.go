@@ -0,0 +1,146 @@
+package env
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestReaderInterpolation(t *testing.T) {
+	reader := New(MapSource{
+		"HOST":      "db.internal",
+		"PORT":      "5432",
+		"URL":       "postgres://${HOST}:${PORT}/app",
+		"NESTED":    "${URL}?sslmode=${SSL_MODE:-disable}",
+		"FALLBACK":  "${MISSING:-${HOST}}",
+		"ESCAPED":   "price: $$5 and $HOST",
+		"ENCODED":   "base64:ZGIuaW50ZXJuYWw=",
+		"REFERENCE": "${ENCODED}",
+		"EMPTY":     "",
+		"DEFAULTED": "${EMPTY:-fallback}",
+		"PLAIN_INT": "${PORT}",
+	}, WithInterpolation())
+
+	cases := map[string]string{
+		"URL":       "postgres://db.internal:5432/app",
+		"NESTED":    "postgres://db.internal:5432/app?sslmode=disable",
+		"FALLBACK":  "db.internal",
+		"ESCAPED":   "price: $5 and $HOST",
+		"REFERENCE": "db.internal",
+		"DEFAULTED": "fallback",
+	}
+
+	for key, expected := range cases {
+		value, err := reader.GetStringOrError(key)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", key, err)
+			continue
+		}
+		if value != expected {
+			t.Errorf("%s: expected '%s', got '%s'", key, expected, value)
+		}
+	}
+
+	if value := reader.GetInt("PLAIN_INT"); value != 5432 {
+		t.Errorf("Expected 5432, got %d", value)
+	}
+}
+
+func TestReaderInterpolationDisabled(t *testing.T) {
+	reader := New(MapSource{"HOST": "db.internal", "URL": "${HOST}:$$"})
+
+	if value := reader.GetString("URL"); value != "${HOST}:$$" {
+		t.Errorf("Expected value to be left untouched, got '%s'", value)
+	}
+}
+
+func TestReaderInterpolationErrors(t *testing.T) {
+	reader := New(MapSource{
+		"A":            "${B}",
+		"B":            "${C}",
+		"C":            "${A}",
+		"REQUIRED":     "${MISSING:?must be set}",
+		"UNTERMINATED": "${HOST",
+		"UNSUPPORTED":  "${HOST:+x}",
+	}, WithInterpolation())
+
+	_, err := reader.GetStringOrError("A")
+	var interpolationErr *InterpolationError
+	if !errors.As(err, &interpolationErr) {
+		t.Fatalf("Expected *InterpolationError, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "A -> B -> C -> A") {
+		t.Errorf("Expected error to name the cycle, got '%s'", err)
+	}
+
+	_, err = reader.GetStringOrError("REQUIRED")
+	if err == nil || !strings.Contains(err.Error(), "REQUIRED -> MISSING") || !strings.Contains(err.Error(), "must be set") {
+		t.Errorf("Expected error naming REQUIRED -> MISSING with message, got '%v'", err)
+	}
+
+	for _, key := range []string{"UNTERMINATED", "UNSUPPORTED"} {
+		if _, err := reader.GetStringOrError(key); err == nil {
+			t.Errorf("%s: expected error, got nil", key)
+		}
+	}
+
+	secrets := New(MapSource{
+		"DB_PASSWORD": "hunter2${oops",
+		"API_TOKEN":   "tok-123${HOST:+tok-456}",
+		"SECRET_KEY":  "key-789${:-key-000}",
+	}, WithInterpolation())
+
+	for _, key := range []string{"DB_PASSWORD", "API_TOKEN", "SECRET_KEY"} {
+		_, err := secrets.GetStringOrError(key)
+		if err == nil || !strings.Contains(err.Error(), key) {
+			t.Fatalf("%s: expected error naming the key, got %v", key, err)
+		}
+		for _, secret := range []string{"hunter2", "oops", "tok-", "key-"} {
+			if strings.Contains(err.Error(), secret) {
+				t.Errorf("%s: expected the value to stay out of the error, got '%s'", key, err)
+			}
+		}
+	}
+
+	if value := reader.GetStringOrDefault("A", "default"); value != "default" {
+		t.Errorf("Expected 'default', got '%s'", value)
+	}
+
+	if _, err := reader.GetIntOrError("A"); err == nil {
+		t.Error("Expected error, got nil")
+	}
+}
+
+func TestExpand(t *testing.T) {
+	os.Setenv("TEST_EXPAND_HOST", "localhost")
+	defer os.Unsetenv("TEST_EXPAND_HOST")
+
+	value, err := Expand("http://${TEST_EXPAND_HOST}:${TEST_EXPAND_PORT:-8080}")
+	if err != nil {
+		t.Fatalf("Expected nil error, got '%s'", err)
+	}
+	if value != "http://localhost:8080" {
+		t.Errorf("Expected 'http://localhost:8080', got '%s'", value)
+	}
+}
+
+func TestSetDefault(t *testing.T) {
+	os.Setenv("TEST_SET_DEFAULT_HOST", "localhost")
+	os.Setenv("TEST_SET_DEFAULT_URL", "http://${TEST_SET_DEFAULT_HOST}")
+	defer os.Unsetenv("TEST_SET_DEFAULT_HOST")
+	defer os.Unsetenv("TEST_SET_DEFAULT_URL")
+
+	SetDefault(New(OSSource(), WithInterpolation()))
+	defer SetDefault(nil)
+
+	if value := GetString("TEST_SET_DEFAULT_URL"); value != "http://localhost" {
+		t.Errorf("Expected 'http://localhost', got '%s'", value)
+	}
+
+	SetDefault(nil)
+
+	if value := GetString("TEST_SET_DEFAULT_URL"); value != "http://${TEST_SET_DEFAULT_HOST}" {
+		t.Errorf("Expected uninterpolated value, got '%s'", value)
+	}
+}
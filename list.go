@@ -0,0 +1,195 @@
+package env
+
+import (
+	"fmt"
+	"strings"
+)
+
+// EmptyPolicy controls how list getters treat empty elements, such as the
+// middle element of "a,,b".
+type EmptyPolicy int
+
+const (
+	// EmptySkip drops empty elements.
+	EmptySkip EmptyPolicy = iota
+
+	// EmptyKeep keeps empty elements as zero-length strings.
+	EmptyKeep
+
+	// EmptyError reports empty elements as an error.
+	EmptyError
+)
+
+// ListOption configures how list and map getters split a value.
+type ListOption func(*listConfig)
+
+// listConfig holds the settings applied by ListOption values.
+type listConfig struct {
+	separator         string
+	keyValueSeparator string
+	trim              bool
+	empty             EmptyPolicy
+}
+
+// WithSeparator sets the separator between elements. Defaults to ",".
+func WithSeparator(separator string) ListOption {
+	return func(c *listConfig) {
+		c.separator = separator
+	}
+}
+
+// WithKeyValueSeparator sets the separator between a key and its value in
+// map getters. Defaults to "=".
+func WithKeyValueSeparator(separator string) ListOption {
+	return func(c *listConfig) {
+		c.keyValueSeparator = separator
+	}
+}
+
+// WithoutTrim keeps the whitespace around unquoted elements, which is
+// trimmed by default.
+func WithoutTrim() ListOption {
+	return func(c *listConfig) {
+		c.trim = false
+	}
+}
+
+// WithEmptyElements sets the policy for empty elements. Defaults to EmptySkip.
+func WithEmptyElements(policy EmptyPolicy) ListOption {
+	return func(c *listConfig) {
+		c.empty = policy
+	}
+}
+
+// newListConfig returns the default configuration with options applied.
+func newListConfig(options []ListOption) listConfig {
+	config := listConfig{
+		separator:         ",",
+		keyValueSeparator: "=",
+		trim:              true,
+		empty:             EmptySkip,
+	}
+
+	for _, option := range options {
+		option(&config)
+	}
+
+	return config
+}
+
+// splitList splits value into elements. It also returns the position of
+// each element in value, counting skipped empty elements, so that errors
+// can point to the element as written.
+//
+// An element starting with a double or single quote runs until the
+// matching unescaped quote, so it may contain the separator; the quotes
+// are removed and \ escapes the next character. Quoted elements are never
+// trimmed or treated as empty.
+func splitList(key string, value string, config listConfig) ([]string, []int, error) {
+	if config.separator == "" {
+		return nil, nil, fmt.Errorf("environment variable '%s': list separator cannot be empty", key)
+	}
+
+	elements := []string{}
+	indexes := []int{}
+	position := 0
+
+	for index := 0; ; index++ {
+		element, quoted, next, err := nextListElement(value, position, config)
+		if err != nil {
+			return nil, nil, invalidError(key, value, "list", fmt.Errorf("element %d: %w", index, err))
+		}
+
+		switch {
+		case quoted || element != "" || config.empty == EmptyKeep:
+			elements = append(elements, element)
+			indexes = append(indexes, index)
+		case config.empty == EmptyError:
+			return nil, nil, invalidError(key, value, "list", fmt.Errorf("element %d is empty", index))
+		}
+
+		if next < 0 {
+			return elements, indexes, nil
+		}
+
+		position = next
+	}
+}
+
+// nextListElement reads the element starting at position. It returns the
+// element, whether it was quoted, and the position after the following
+// separator, or -1 if the element was the last one.
+func nextListElement(value string, position int, config listConfig) (string, bool, int, error) {
+	start := position
+
+	if config.trim {
+		for start < len(value) && isListSpace(value[start]) {
+			start++
+		}
+	}
+
+	if start < len(value) && (value[start] == '"' || value[start] == '\'') {
+		return nextQuotedElement(value, start, config)
+	}
+
+	end := strings.Index(value[position:], config.separator)
+	if end < 0 {
+		return trimListElement(value[position:], config), false, -1, nil
+	}
+
+	end += position
+
+	return trimListElement(value[position:end], config), false, end + len(config.separator), nil
+}
+
+// nextQuotedElement reads the quoted element whose opening quote is at start.
+func nextQuotedElement(value string, start int, config listConfig) (string, bool, int, error) {
+	quote := value[start]
+
+	var element strings.Builder
+
+	i := start + 1
+	for ; i < len(value); i++ {
+		if value[i] == '\\' && i+1 < len(value) {
+			i++
+			element.WriteByte(value[i])
+			continue
+		}
+		if value[i] == quote {
+			break
+		}
+		element.WriteByte(value[i])
+	}
+
+	if i >= len(value) {
+		return "", true, 0, fmt.Errorf("unterminated quote in %q", value[start:])
+	}
+
+	rest := i + 1
+	for rest < len(value) && isListSpace(value[rest]) {
+		rest++
+	}
+
+	if rest == len(value) {
+		return element.String(), true, -1, nil
+	}
+
+	if !strings.HasPrefix(value[rest:], config.separator) {
+		return "", true, 0, fmt.Errorf("unexpected %q after quoted element", value[rest:])
+	}
+
+	return element.String(), true, rest + len(config.separator), nil
+}
+
+// trimListElement trims an unquoted element when trimming is enabled.
+func trimListElement(element string, config listConfig) string {
+	if config.trim {
+		return strings.TrimSpace(element)
+	}
+	return element
+}
+
+// isListSpace reports whether c is whitespace around a list element.
+func isListSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
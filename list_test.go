@@ -0,0 +1,58 @@
+package env
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitListIndexes(t *testing.T) {
+	_, indexes, err := splitList("TEST_LIST", `a,, "b" ,,c`, newListConfig(nil))
+	if err != nil {
+		t.Fatalf("Expected nil error, got '%s'", err)
+	}
+	if !reflect.DeepEqual(indexes, []int{0, 2, 4}) {
+		t.Errorf("Expected [0 2 4], got %v", indexes)
+	}
+}
+
+func TestSplitList(t *testing.T) {
+	cases := []struct {
+		value     string
+		options   []ListOption
+		expect    []string
+		shouldErr bool
+	}{
+		{"a,b,c", nil, []string{"a", "b", "c"}, false},
+		{" a , b ,c ", nil, []string{"a", "b", "c"}, false},
+		{"a,,b,", nil, []string{"a", "b"}, false},
+		{"a,,b", []ListOption{WithEmptyElements(EmptyKeep)}, []string{"a", "", "b"}, false},
+		{"a,,b", []ListOption{WithEmptyElements(EmptyError)}, nil, true},
+		{" a , b ", []ListOption{WithoutTrim()}, []string{" a ", " b "}, false},
+		{"a;b;c", []ListOption{WithSeparator(";")}, []string{"a", "b", "c"}, false},
+		{"a::b", []ListOption{WithSeparator("::")}, []string{"a", "b"}, false},
+		{`"a,b",c`, nil, []string{"a,b", "c"}, false},
+		{`'a,b' , "c \"d\""`, nil, []string{"a,b", `c "d"`}, false},
+		{`"",a`, nil, []string{"", "a"}, false},
+		{`" padded "`, nil, []string{" padded "}, false},
+		{`"unterminated,a`, nil, nil, true},
+		{`"a"b,c`, nil, nil, true},
+		{"a,b", []ListOption{WithSeparator("")}, nil, true},
+	}
+
+	for i, c := range cases {
+		got, _, err := splitList("TEST_LIST", c.value, newListConfig(c.options))
+		if c.shouldErr {
+			if err == nil {
+				t.Errorf("case %d (%q): expected error, got nil (value=%q)", i, c.value, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("case %d (%q): unexpected error: %v", i, c.value, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, c.expect) {
+			t.Errorf("case %d (%q): expected %q, got %q", i, c.value, c.expect, got)
+		}
+	}
+}
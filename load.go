@@ -1,15 +1,67 @@
 package env
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
 	"log"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
 
+// LoadError describes a .env file that could not be loaded.
+type LoadError struct {
+	// Path is the path of the file that failed to load.
+	Path string
+
+	// Line is the 1-based line where parsing failed, or 0 if unknown.
+	Line int
+
+	// Err is the underlying error. Parse errors never include the file
+	// content, which may hold secrets; Line gives the location instead.
+	Err error
+
+	// malformed is set when the file was read but could not be parsed.
+	malformed bool
+}
+
+// Error returns the error message, including the line when known.
+func (e *LoadError) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("error loading %s file: line %d: %v", e.Path, e.Line, e.Err)
+	}
+	return fmt.Sprintf("error loading %s file: %v", e.Path, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *LoadError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether the error matches target. A missing file matches
+// ErrNotFound and a file that cannot be parsed matches ErrInvalid.
+func (e *LoadError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return errors.Is(e.Err, fs.ErrNotExist)
+	case ErrInvalid:
+		return e.malformed
+	}
+	return false
+}
+
 // Load loads environment variables from .env files.
 //
 // If no paths are provided, it will try to load the default .env file.
 //
+// Load terminates the program if a file cannot be loaded. Use LoadE to
+// handle the error instead.
+//
 // Parameters:
 //
 //	...envFilePath: The paths to the .env files to load.
@@ -18,16 +70,151 @@ import (
 //
 //	None.
 func Load(envFilePath ...string) {
+	if err := LoadE(envFilePath...); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// LoadE loads environment variables from .env files, returning an error
+// instead of terminating the program.
+//
+// The default .env file is tried first, followed by the given paths.
+// Missing files are skipped. Variables that are already set are not
+// overwritten.
+//
+// Parameters:
+//
+//	...envFilePath: The paths to the .env files to load.
+//
+// Returns:
+//
+//	A *LoadError if a file cannot be read or parsed, otherwise nil.
+func LoadE(envFilePath ...string) error {
 	paths := []string{".env"}
 
 	paths = append(paths, envFilePath...)
 
+	_, err := LoadWithOptions(LoadOptions{
+		Paths:    paths,
+		Override: OverrideKeep,
+	})
+
+	return err
+}
+
+// LoadOptions configures LoadWithOptions.
+type LoadOptions struct {
+	// Paths lists the .env files to load, in order. Missing files are
+	// skipped. Defaults to ".env" when empty.
+	Paths []string
+
+	// Override controls what happens to variables that are already set.
+	// Files are applied in order, so with OverrideKeep the first file that
+	// sets a key wins, and with OverrideReplace the last one does.
+	Override OverridePolicy
+}
+
+// LoadWithOptions loads environment variables from .env files using the
+// given override policy.
+//
+// Parameters:
+//
+//	options: The files to load and the override policy.
+//
+// Returns:
+//
+//	A report of the keys that were set, replaced or skipped, and an error.
+//	The error is a *LoadError if a file cannot be read or parsed, or a
+//	*ConflictError if OverrideFail found a conflict. Nothing is set when
+//	an error is returned, except on a failing os.Setenv.
+func LoadWithOptions(options LoadOptions) (*LoadReport, error) {
+	paths := options.Paths
+	if len(paths) == 0 {
+		paths = []string{".env"}
+	}
+
+	batches := []valueBatch{}
+
 	for _, path := range paths {
-		if fileExists(path) {
-			err := godotenv.Load(path)
-			if err != nil {
-				log.Fatal("Error loading " + path + " file")
-			}
+		if !fileExists(path) {
+			continue
+		}
+
+		values, err := readEnvFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		batches = append(batches, valueBatch{origin: path, values: values})
+	}
+
+	return applyBatches(batches, options.Override)
+}
+
+// readEnvFile reads and parses the .env file at path.
+func readEnvFile(path string) (map[string]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, &LoadError{Path: path, Err: err}
+	}
+
+	values, err := godotenv.Parse(bytes.NewReader(content))
+	if err != nil {
+		return nil, &LoadError{Path: path, Line: parseErrorLine(content, err), Err: parseErrorReason(err), malformed: true}
+	}
+
+	return values, nil
+}
+
+// unexpectedRe matches the start of godotenv's "unexpected character"
+// errors, up to where the file content begins.
+var unexpectedRe = regexp.MustCompile(`^unexpected character ("(?:[^"\\]|\\.)*") in variable name`)
+
+// parseErrorReason returns a copy of a godotenv parse error without the
+// file content that godotenv appends to some of its messages.
+func parseErrorReason(parseErr error) error {
+	message := parseErr.Error()
+
+	switch {
+	case unexpectedRe.MatchString(message):
+		return errors.New(unexpectedRe.FindString(message))
+	case strings.HasPrefix(message, "unterminated quoted value"):
+		return errors.New("unterminated quoted value")
+	case message == "zero length string":
+		return parseErr
+	}
+
+	return errors.New("invalid syntax")
+}
+
+// nearRe extracts the unparsed remainder that godotenv reports in its
+// "unexpected character" errors.
+var nearRe = regexp.MustCompile(` near ("(?:[^"\\]|\\.)*")$`)
+
+// parseErrorLine returns the 1-based line at which content fails to parse,
+// or 0 if it cannot be determined.
+//
+// When the parser reports where it stopped, the line is derived from that
+// position. Otherwise the content is shrunk one line at a time until the
+// remaining prefix parses.
+func parseErrorLine(content []byte, parseErr error) int {
+	normalized := strings.ReplaceAll(string(content), "\r\n", "\n")
+
+	if match := nearRe.FindStringSubmatch(parseErr.Error()); match != nil {
+		if rest, err := strconv.Unquote(match[1]); err == nil && rest != "" && strings.HasSuffix(normalized, rest) {
+			offset := len(normalized) - len(rest)
+			return strings.Count(normalized[:offset], "\n") + 1
+		}
+	}
+
+	lines := strings.Split(normalized, "\n")
+
+	for n := len(lines) - 1; n >= 0; n-- {
+		prefix := strings.Join(lines[:n], "\n")
+		if _, err := godotenv.Unmarshal(prefix); err == nil {
+			return n + 1
 		}
 	}
+
+	return 0
 }
@@ -0,0 +1,169 @@
+package env
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultEnvironmentVariable is the variable that names the current
+// environment when LoadEnvironment is not given one explicitly.
+const DefaultEnvironmentVariable = "APP_ENV"
+
+// EnvironmentOptions configures LoadEnvironment.
+type EnvironmentOptions struct {
+	// Environment is the name of the environment, e.g. "production".
+	// When empty, it is read from Variable.
+	Environment string
+
+	// Variable is the environment variable holding the environment name.
+	// Defaults to DefaultEnvironmentVariable.
+	Variable string
+
+	// Dir is the directory holding the .env files. Defaults to the
+	// current directory.
+	Dir string
+
+	// Override controls what happens to variables that are already set
+	// in the process environment.
+	Override OverridePolicy
+}
+
+// EnvironmentReport describes the result of LoadEnvironment.
+type EnvironmentReport struct {
+	LoadReport
+
+	// Environment is the resolved environment name, or empty if none.
+	Environment string
+
+	// Candidates lists every file of the cascade, highest precedence first.
+	Candidates []string
+
+	// Applied lists the files that were found and applied, highest
+	// precedence first.
+	Applied []string
+}
+
+// LoadForEnvironment loads the standard .env cascade for the named
+// environment, keeping variables that are already set.
+//
+// If name is empty, it is read from the APP_ENV variable. See
+// LoadEnvironment for the cascade and its precedence.
+//
+// Parameters:
+//
+//	name: The environment name, e.g. "production".
+//
+// Returns:
+//
+//	A report of the files and keys applied, and an error if loading fails.
+func LoadForEnvironment(name string) (*EnvironmentReport, error) {
+	return LoadEnvironment(EnvironmentOptions{Environment: name})
+}
+
+// LoadEnvironment loads the standard .env cascade. From highest to lowest
+// precedence the files are:
+//
+//	.env.<environment>.local
+//	.env.local (skipped when the environment is "test")
+//	.env.<environment>
+//	.env
+//
+// Missing files are skipped. A key defined in several files takes the
+// value from the file with the highest precedence; the override policy
+// then decides how that value interacts with the process environment.
+//
+// Parameters:
+//
+//	options: The environment, directory and override policy.
+//
+// Returns:
+//
+//	A report of the files and keys applied, and an error if loading fails.
+func LoadEnvironment(options EnvironmentOptions) (*EnvironmentReport, error) {
+	environment := strings.TrimSpace(options.Environment)
+
+	if environment == "" {
+		variable := options.Variable
+		if variable == "" {
+			variable = DefaultEnvironmentVariable
+		}
+		environment = GetString(variable)
+	}
+
+	if strings.ContainsAny(environment, `/\`) || strings.Contains(environment, "..") {
+		return nil, errors.New("invalid environment name: " + environment)
+	}
+
+	report := &EnvironmentReport{
+		Environment: environment,
+		Candidates:  environmentFiles(options.Dir, environment),
+	}
+
+	merged := map[string]string{}
+
+	// Apply from lowest to highest precedence so higher files overwrite
+	for i := len(report.Candidates) - 1; i >= 0; i-- {
+		path := report.Candidates[i]
+
+		if !fileExists(path) {
+			continue
+		}
+
+		values, err := readEnvFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		for key, value := range values {
+			merged[key] = value
+		}
+
+		report.Applied = append([]string{path}, report.Applied...)
+	}
+
+	if len(report.Applied) == 0 {
+		return report, nil
+	}
+
+	batch := valueBatch{
+		origin: strings.Join(report.Applied, ", "),
+		values: merged,
+	}
+
+	loadReport, err := applyBatches([]valueBatch{batch}, options.Override)
+	if err != nil {
+		return nil, err
+	}
+
+	report.LoadReport = *loadReport
+
+	return report, nil
+}
+
+// environmentFiles returns the cascade of .env files for the environment,
+// highest precedence first.
+func environmentFiles(dir string, environment string) []string {
+	names := []string{}
+
+	if environment != "" {
+		names = append(names, ".env."+environment+".local")
+	}
+
+	if environment != "test" {
+		names = append(names, ".env.local")
+	}
+
+	if environment != "" {
+		names = append(names, ".env."+environment)
+	}
+
+	names = append(names, ".env")
+
+	paths := make([]string, len(names))
+	for i, name := range names {
+		paths[i] = filepath.Join(dir, name)
+	}
+
+	return paths
+}
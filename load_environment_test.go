@@ -0,0 +1,91 @@
+package env
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoadEnvironment(t *testing.T) {
+	dir := t.TempDir()
+
+	files := map[string]string{
+		".env":                  "TEST_CASCADE_BASE=base\nTEST_CASCADE_A=base\nTEST_CASCADE_B=base\n",
+		".env.local":            "TEST_CASCADE_A=local\n",
+		".env.production":       "TEST_CASCADE_A=production\nTEST_CASCADE_B=production\n",
+		".env.production.local": "TEST_CASCADE_B=production_local\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Error writing file: %v", err)
+		}
+	}
+
+	os.Setenv("TEST_CASCADE_ENV", "production")
+	defer os.Unsetenv("TEST_CASCADE_ENV")
+	defer os.Unsetenv("TEST_CASCADE_BASE")
+	defer os.Unsetenv("TEST_CASCADE_A")
+	defer os.Unsetenv("TEST_CASCADE_B")
+
+	report, err := LoadEnvironment(EnvironmentOptions{
+		Variable: "TEST_CASCADE_ENV",
+		Dir:      dir,
+	})
+	if err != nil {
+		t.Fatalf("Expected nil error, got '%s'", err)
+	}
+
+	if report.Environment != "production" {
+		t.Errorf("Expected 'production', got '%s'", report.Environment)
+	}
+
+	expectedFiles := []string{
+		filepath.Join(dir, ".env.production.local"),
+		filepath.Join(dir, ".env.local"),
+		filepath.Join(dir, ".env.production"),
+		filepath.Join(dir, ".env"),
+	}
+	if !reflect.DeepEqual(report.Applied, expectedFiles) {
+		t.Errorf("Expected applied %v, got %v", expectedFiles, report.Applied)
+	}
+
+	expected := map[string]string{
+		"TEST_CASCADE_BASE": "base",
+		"TEST_CASCADE_A":    "local",
+		"TEST_CASCADE_B":    "production_local",
+	}
+	for key, value := range expected {
+		if os.Getenv(key) != value {
+			t.Errorf("Expected %s to be '%s', got '%s'", key, value, os.Getenv(key))
+		}
+	}
+}
+
+func TestLoadEnvironmentMissingFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	report, err := LoadEnvironment(EnvironmentOptions{Environment: "test", Dir: dir})
+	if err != nil {
+		t.Fatalf("Expected nil error, got '%s'", err)
+	}
+
+	expected := []string{
+		filepath.Join(dir, ".env.test.local"),
+		filepath.Join(dir, ".env.test"),
+		filepath.Join(dir, ".env"),
+	}
+	if !reflect.DeepEqual(report.Candidates, expected) {
+		t.Errorf("Expected candidates %v, got %v", expected, report.Candidates)
+	}
+	if len(report.Applied) != 0 {
+		t.Errorf("Expected no applied files, got %v", report.Applied)
+	}
+}
+
+func TestLoadEnvironmentInvalidName(t *testing.T) {
+	_, err := LoadForEnvironment("../production")
+	if err == nil {
+		t.Error("Expected error, got nil")
+	}
+}
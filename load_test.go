@@ -1,8 +1,14 @@
 package env
 
 import (
+	"errors"
 	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+
+	"github.com/joho/godotenv"
 )
 
 func TestLoad(t *testing.T) {
@@ -28,3 +34,151 @@ func TestLoad(t *testing.T) {
 		t.Errorf("Expected TEST_VAR to be 'test_value', but got '%s'", os.Getenv("TEST_VAR"))
 	}
 }
+
+func TestLoadE(t *testing.T) {
+	tempFile, err := os.CreateTemp("", "test.env")
+	if err != nil {
+		t.Fatalf("Error creating temporary file: %v", err)
+	}
+	defer os.Remove(tempFile.Name())
+
+	_, err = tempFile.WriteString("TEST_LOADE_VAR=loaded\nTEST_LOADE_EXISTING=from_file\n")
+	tempFile.Close()
+	if err != nil {
+		t.Fatalf("Error writing to temporary file: %v", err)
+	}
+
+	os.Setenv("TEST_LOADE_EXISTING", "from_env")
+	defer os.Unsetenv("TEST_LOADE_EXISTING")
+	defer os.Unsetenv("TEST_LOADE_VAR")
+
+	err = LoadE(tempFile.Name(), "non_existent.env")
+	if err != nil {
+		t.Fatalf("Expected nil error, got '%s'", err)
+	}
+
+	if os.Getenv("TEST_LOADE_VAR") != "loaded" {
+		t.Errorf("Expected 'loaded', got '%s'", os.Getenv("TEST_LOADE_VAR"))
+	}
+	if os.Getenv("TEST_LOADE_EXISTING") != "from_env" {
+		t.Errorf("Expected 'from_env', got '%s'", os.Getenv("TEST_LOADE_EXISTING"))
+	}
+}
+
+func TestLoadEInvalidFile(t *testing.T) {
+	tempFile, err := os.CreateTemp("", "test.env")
+	if err != nil {
+		t.Fatalf("Error creating temporary file: %v", err)
+	}
+	defer os.Remove(tempFile.Name())
+
+	_, err = tempFile.WriteString("# comment\nTEST_LOADE_OK=1\nTEST LOADE BAD\nTEST_LOADE_AFTER=2\n")
+	tempFile.Close()
+	if err != nil {
+		t.Fatalf("Error writing to temporary file: %v", err)
+	}
+
+	err = LoadE(tempFile.Name())
+	if err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+
+	var loadErr *LoadError
+	if !errors.As(err, &loadErr) {
+		t.Fatalf("Expected *LoadError, got %T", err)
+	}
+	if loadErr.Path != tempFile.Name() {
+		t.Errorf("Expected path '%s', got '%s'", tempFile.Name(), loadErr.Path)
+	}
+	if loadErr.Line != 3 {
+		t.Errorf("Expected line 3, got %d", loadErr.Line)
+	}
+}
+
+func TestLoadEErrorHidesContent(t *testing.T) {
+	contents := []string{
+		"A=1\nB=2\nTEST LOADE BAD\nDB_PASSWORD=hunter2\nAPI_TOKEN=tok-123\n",
+		"A=1\nB=\"quoted-secret\nDB_PASSWORD=hunter2\nAPI_TOKEN=tok-123\n",
+	}
+
+	for i, content := range contents {
+		path := filepath.Join(t.TempDir(), "bad.env")
+		os.WriteFile(path, []byte(content), 0o600)
+
+		err := LoadE(path)
+		if err == nil {
+			t.Fatalf("case %d: expected error, got nil", i)
+		}
+
+		for _, secret := range []string{"hunter2", "tok-123", "quoted-secret"} {
+			if strings.Contains(err.Error(), secret) {
+				t.Errorf("case %d: expected the error not to contain '%s', got '%s'", i, secret, err)
+			}
+		}
+
+		if !errors.Is(err, ErrInvalid) || !strings.Contains(err.Error(), "line ") {
+			t.Errorf("case %d: expected an invalid file error with its line, got '%s'", i, err)
+		}
+	}
+}
+
+func TestParseErrorLine(t *testing.T) {
+	cases := []struct {
+		content string
+		line    int
+	}{
+		{"A=1\nB-C=2\nD=3\n", 2},
+		{"A=1\r\n\r\nB C\r\nD=3\r\n", 3},
+		{"A=1\nB=\"unterminated\nC=2\n", 2},
+	}
+
+	for i, c := range cases {
+		_, err := godotenv.Unmarshal(c.content)
+		if err == nil {
+			t.Fatalf("case %d: expected parse error, got nil", i)
+		}
+		if line := parseErrorLine([]byte(c.content), err); line != c.line {
+			t.Errorf("case %d: expected line %d, got %d", i, c.line, line)
+		}
+	}
+}
+
+func TestLoadWithOptions(t *testing.T) {
+	dir := t.TempDir()
+	first := filepath.Join(dir, "first.env")
+	second := filepath.Join(dir, "second.env")
+
+	if err := os.WriteFile(first, []byte("TEST_LOADOPT_VAR=first\n"), 0644); err != nil {
+		t.Fatalf("Error writing file: %v", err)
+	}
+	if err := os.WriteFile(second, []byte("TEST_LOADOPT_VAR=second\n"), 0644); err != nil {
+		t.Fatalf("Error writing file: %v", err)
+	}
+	defer os.Unsetenv("TEST_LOADOPT_VAR")
+
+	report, err := LoadWithOptions(LoadOptions{
+		Paths:    []string{first, second},
+		Override: OverrideReplace,
+	})
+	if err != nil {
+		t.Fatalf("Expected nil error, got '%s'", err)
+	}
+	if os.Getenv("TEST_LOADOPT_VAR") != "second" {
+		t.Errorf("Expected 'second', got '%s'", os.Getenv("TEST_LOADOPT_VAR"))
+	}
+	if !reflect.DeepEqual(report.Set, []string{"TEST_LOADOPT_VAR"}) {
+		t.Errorf("Expected Set [TEST_LOADOPT_VAR], got %v", report.Set)
+	}
+
+	_, err = LoadWithOptions(LoadOptions{
+		Paths:    []string{first},
+		Override: OverrideFail,
+	})
+	var conflictErr *ConflictError
+	if !errors.As(err, &conflictErr) {
+		t.Fatalf("Expected *ConflictError, got %v", err)
+	}
+	if conflictErr.Origin != first {
+		t.Errorf("Expected origin '%s', got '%s'", first, conflictErr.Origin)
+	}
+}
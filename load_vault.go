@@ -2,13 +2,82 @@ package env
 
 import (
 	"errors"
-	"os"
+	"fmt"
+	"path"
+	"strings"
 
 	"github.com/dracory/envenc"
 )
 
+// vaultIDKey is the internal identifier that envenc stores in every vault.
+const vaultIDKey = "id"
+
+// ProtectedVariables lists the names, or path.Match patterns, of variables
+// that a vault may not set when VaultOptions.RejectProtected is true:
+// variables that change how programs are found, linked or run.
+var ProtectedVariables = []string{
+	"PATH",
+	"HOME",
+	"SHELL",
+	"USER",
+	"LOGNAME",
+	"IFS",
+	"ENV",
+	"BASH_ENV",
+	"PROMPT_COMMAND",
+	"PS4",
+	"GCONV_PATH",
+	"LD_*",
+	"DYLD_*",
+}
+
+// VaultOptions configures the vault functions.
+type VaultOptions struct {
+	// Password is the password used to decrypt the vault. Surrounding
+	// whitespace is ignored, as envenc ignores it when reading a vault.
+	Password string
+
+	// PasswordSources are tried in order when Password is empty, e.g.
+	// PasswordFromFile("/run/secrets/vault"), PasswordFromEnv("VAULT_PASSWORD")
+	// or PasswordFromPrompt("Vault password: "). See ResolvePassword.
+	PasswordSources []PasswordSource
+
+	// VaultFilePath is the path to the vault file to load.
+	VaultFilePath string
+
+	// VaultContent is the content of the vault to load.
+	VaultContent string
+
+	// Override controls what happens to variables that are already set.
+	Override OverridePolicy
+
+	// Keys lists the names, or path.Match patterns such as "BILLING_*", of
+	// the vault keys to load. When empty, every key is loaded. Keys,
+	// StripPrefix, AddPrefix and RejectProtected apply when a vault is
+	// read; the functions that modify vaults ignore them.
+	Keys []string
+
+	// StripPrefix is removed from the selected keys, e.g. "BILLING_" loads
+	// BILLING_API_KEY as API_KEY. Keys without the prefix are skipped.
+	StripPrefix string
+
+	// AddPrefix is prepended to the selected keys after StripPrefix is
+	// removed.
+	AddPrefix string
+
+	// RejectProtected makes loading fail if a key, after renaming, matches
+	// one of ProtectedVariables.
+	RejectProtected bool
+}
+
 // LoadVault loads environment variables from an encrypted vault file or from vault content using the provided password.
 //
+// Variables that are already set are overwritten. Use LoadVaultWithOptions
+// to choose a different override policy.
+//
+// The internal vault identifier, stored by envenc under the key "id", is
+// not loaded; earlier versions set it as the variable id.
+//
 // Parameters:
 //
 //	Password: The password to use for decrypting the vault file or vault content.
@@ -23,48 +92,203 @@ func LoadVault(options struct {
 	VaultFilePath string
 	VaultContent  string
 }) error {
-	if options.Password == "" {
-		return errors.New("password is required")
+	_, err := LoadVaultWithOptions(VaultOptions{
+		Password:      options.Password,
+		VaultFilePath: options.VaultFilePath,
+		VaultContent:  options.VaultContent,
+		Override:      OverrideReplace,
+	})
+
+	return err
+}
+
+// LoadVaultWithOptions loads environment variables from an encrypted vault
+// file or from vault content using the given override policy.
+//
+// Parameters:
+//
+//	options: The vault to load, its password and the override policy.
+//
+// Returns:
+//
+//	A report of the keys that were set, replaced or skipped, and an error
+//	if loading fails. A *ConflictError is returned if OverrideFail found
+//	a conflict, in which case nothing is set.
+func LoadVaultWithOptions(options VaultOptions) (*LoadReport, error) {
+	keys, err := readVault(options)
+	if err != nil {
+		return nil, err
 	}
 
-	if options.VaultFilePath == "" && options.VaultContent == "" {
-		return errors.New("vault file path or vault content is required")
+	origin := options.VaultFilePath
+	if origin == "" {
+		origin = "vault content"
 	}
 
-	if options.VaultFilePath != "" && options.VaultContent != "" {
-		return errors.New("vault file path and vault content are mutually exclusive")
+	return applyBatches([]valueBatch{{origin: origin, values: keys}}, options.Override)
+}
+
+// LoadVaultStore decrypts a vault into a read-only, in-memory store and
+// returns a Reader over it. Unlike LoadVault, nothing is written to the
+// process environment, so the values do not leak into child processes,
+// /proc/self/environ or crash dumps.
+//
+// The Reader has the same getter families as the package-level functions,
+// and values are processed (base64:, obfuscated:) the same way. Every value
+// is treated as secret and redacted in error messages. The Reader only
+// sees the vault; to fall back to the environment, build one from
+// VaultList with New(StackSource(MapSource(keys), OSSource())).
+//
+// Parameters:
+//
+//	options: The vault to load and its password. Override is ignored.
+//	readerOptions: Options for the returned Reader, e.g. WithStrict().
+//
+// Returns:
+//
+//	The Reader, and an error if loading fails.
+func LoadVaultStore(options VaultOptions, readerOptions ...Option) (*Reader, error) {
+	keys, err := readVault(options)
+	if err != nil {
+		return nil, err
+	}
+
+	r := New(MapSource(keys), readerOptions...)
+	r.secret = true
+
+	return r, nil
+}
+
+// readVault validates the options and decrypts the vault they point to.
+func readVault(options VaultOptions) (map[string]string, error) {
+	if err := checkVaultOptions(options); err != nil {
+		return nil, err
+	}
+
+	password, err := resolveVaultPassword(options)
+	if err != nil {
+		return nil, err
 	}
 
-	var err error
 	keys := map[string]string{}
 
 	if options.VaultFilePath != "" {
-		if !fileExists(options.VaultFilePath) {
-			return errors.New("Vault file not found: " + options.VaultFilePath)
+		keys, err = envenc.KeyListFromFile(options.VaultFilePath, password)
+	} else {
+		keys, err = envenc.KeyListFromString(options.VaultContent, password)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	// The vault store keeps its own identifier under "id"; it is not a key
+	delete(keys, vaultIDKey)
+
+	return selectVaultKeys(keys, options)
+}
+
+// selectVaultKeys applies the Keys, StripPrefix, AddPrefix and
+// RejectProtected options to the keys of a vault.
+func selectVaultKeys(keys map[string]string, options VaultOptions) (map[string]string, error) {
+	selected := make(map[string]string, len(keys))
+
+	for _, key := range sortedKeys(keys) {
+		if len(options.Keys) > 0 {
+			matched, err := matchAny(options.Keys, key)
+			if err != nil {
+				return nil, err
+			}
+			if !matched {
+				continue
+			}
 		}
 
-		keys, err = envenc.KeyListFromFile(options.VaultFilePath, options.Password)
+		name, ok := strings.CutPrefix(key, options.StripPrefix)
+		if !ok || name == "" {
+			continue
+		}
+		name = options.AddPrefix + name
 
-		if err != nil {
-			return err
+		if options.RejectProtected {
+			protected, err := matchAny(ProtectedVariables, strings.ToUpper(name))
+			if err != nil {
+				return nil, err
+			}
+			if protected {
+				return nil, &sentinelError{
+					message:  fmt.Sprintf("vault key '%s' would set the protected variable '%s'", key, name),
+					sentinel: ErrInvalid,
+				}
+			}
 		}
+
+		selected[name] = keys[key]
 	}
 
-	if options.VaultContent != "" {
-		keys, err = envenc.KeyListFromString(options.VaultContent, options.Password)
+	return selected, nil
+}
 
+// matchAny reports whether name matches one of the path.Match patterns.
+func matchAny(patterns []string, name string) (bool, error) {
+	for _, pattern := range patterns {
+		matched, err := path.Match(pattern, name)
 		if err != nil {
-			return err
+			return false, fmt.Errorf("invalid key pattern '%s': %w", pattern, err)
+		}
+		if matched {
+			return true, nil
 		}
 	}
+	return false, nil
+}
+
+// checkVaultOptions checks that the options name a password and exactly
+// one existing vault.
+func checkVaultOptions(options VaultOptions) error {
+	if options.Password == "" && len(options.PasswordSources) == 0 {
+		return errors.New("password is required")
+	}
+
+	if options.VaultFilePath == "" && options.VaultContent == "" {
+		return errors.New("vault file path or vault content is required")
+	}
+
+	if options.VaultFilePath != "" && options.VaultContent != "" {
+		return errors.New("vault file path and vault content are mutually exclusive")
+	}
 
-	for k, v := range keys {
-		err := os.Setenv(k, v)
+	if options.VaultFilePath != "" && !fileExists(options.VaultFilePath) {
+		return &sentinelError{message: "Vault file not found: " + options.VaultFilePath, sentinel: ErrNotFound}
+	}
+
+	return nil
+}
+
+// resolveVaultPassword returns options.Password, or the password of the
+// first of options.PasswordSources that provides one.
+func resolveVaultPassword(options VaultOptions) (string, error) {
+	password := options.Password
 
+	if password == "" {
+		resolved, err := ResolvePassword(options.PasswordSources...)
 		if err != nil {
-			return err
+			return "", err
 		}
+		password = resolved
 	}
 
-	return nil
+	password = vaultPassword(password)
+	if password == "" {
+		return "", errors.New("password is required")
+	}
+
+	return password, nil
+}
+
+// vaultPassword returns password without surrounding whitespace. envenc
+// trims the password when it reads a vault but not when it writes one, so
+// every vault is written with the trimmed password to stay readable.
+func vaultPassword(password string) string {
+	return strings.TrimSpace(password)
 }
@@ -1,7 +1,11 @@
 package env
 
 import (
+	"errors"
 	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/dracory/envenc"
@@ -53,3 +57,236 @@ func TestLoadVault(t *testing.T) {
 		t.Errorf("Expected TEST_VAULT_VAR to be 'test_vault_value', but got '%s'", os.Getenv("TEST_VAULT_VAR"))
 	}
 }
+
+// createTestVault creates a vault file holding keys and returns its path.
+func createTestVault(t *testing.T, password string, keys map[string]string) string {
+	t.Helper()
+
+	vaultFilePath := filepath.Join(t.TempDir(), "test.vault")
+
+	if err := envenc.Init(vaultFilePath, password); err != nil {
+		t.Fatalf("Error creating vault: %v", err)
+	}
+
+	for key, value := range keys {
+		if err := envenc.KeySet(vaultFilePath, password, key, value); err != nil {
+			t.Fatalf("Error writing to vault: %v", err)
+		}
+	}
+
+	return vaultFilePath
+}
+
+func TestLoadVaultWithOptions(t *testing.T) {
+	password := "password%%1234567890"
+	vaultFilePath := createTestVault(t, password, map[string]string{
+		"TEST_VAULT_NEW":      "new",
+		"TEST_VAULT_EXISTING": "from_vault",
+	})
+
+	os.Setenv("TEST_VAULT_EXISTING", "from_env")
+	defer os.Unsetenv("TEST_VAULT_EXISTING")
+	defer os.Unsetenv("TEST_VAULT_NEW")
+
+	_, err := LoadVaultWithOptions(VaultOptions{
+		Password:      password,
+		VaultFilePath: vaultFilePath,
+		Override:      OverrideFail,
+	})
+	var conflictErr *ConflictError
+	if !errors.As(err, &conflictErr) {
+		t.Fatalf("Expected *ConflictError, got %v", err)
+	}
+	if os.Getenv("TEST_VAULT_NEW") != "" {
+		t.Error("Expected nothing to be set on conflict")
+	}
+
+	report, err := LoadVaultWithOptions(VaultOptions{
+		Password:      password,
+		VaultFilePath: vaultFilePath,
+		Override:      OverrideKeep,
+	})
+	if err != nil {
+		t.Fatalf("Expected nil error, got '%s'", err)
+	}
+	if os.Getenv("TEST_VAULT_EXISTING") != "from_env" {
+		t.Errorf("Expected 'from_env', got '%s'", os.Getenv("TEST_VAULT_EXISTING"))
+	}
+	if !reflect.DeepEqual(report.Set, []string{"TEST_VAULT_NEW"}) {
+		t.Errorf("Expected Set [TEST_VAULT_NEW], got %v", report.Set)
+	}
+	if !reflect.DeepEqual(report.Skipped, []string{"TEST_VAULT_EXISTING"}) {
+		t.Errorf("Expected Skipped [TEST_VAULT_EXISTING], got %v", report.Skipped)
+	}
+
+	err = LoadVault(struct {
+		Password      string
+		VaultFilePath string
+		VaultContent  string
+	}{
+		Password:      password,
+		VaultFilePath: vaultFilePath,
+	})
+	if err != nil {
+		t.Fatalf("Expected nil error, got '%s'", err)
+	}
+	if os.Getenv("TEST_VAULT_EXISTING") != "from_vault" {
+		t.Errorf("Expected LoadVault to override, got '%s'", os.Getenv("TEST_VAULT_EXISTING"))
+	}
+}
+
+func TestLoadVaultWithOptionsValidation(t *testing.T) {
+	cases := []VaultOptions{
+		{VaultFilePath: "test.vault"},
+		{Password: "password"},
+		{Password: "password", VaultFilePath: "test.vault", VaultContent: "content"},
+		{Password: "password", VaultFilePath: "non_existent.vault"},
+	}
+
+	for i, options := range cases {
+		if _, err := LoadVaultWithOptions(options); err == nil {
+			t.Errorf("case %d: expected error, got nil", i)
+		}
+	}
+}
+
+func TestLoadVaultStore(t *testing.T) {
+	path := createTestVault(t, "secret", map[string]string{
+		"TEST_STORE_HOST":    "localhost",
+		"TEST_STORE_PORT":    "5432",
+		"TEST_STORE_DEBUG":   "true",
+		"TEST_STORE_RATIO":   "0.5",
+		"TEST_STORE_ENCODED": "base64:aGVsbG8=",
+		"TEST_STORE_BAD":     "not-a-number",
+	})
+
+	store, err := LoadVaultStore(VaultOptions{Password: "secret", VaultFilePath: path})
+	if err != nil {
+		t.Fatalf("Expected nil error, got '%s'", err)
+	}
+
+	if value := store.GetString("TEST_STORE_HOST"); value != "localhost" {
+		t.Errorf("Expected 'localhost', got '%s'", value)
+	}
+	if value := store.GetInt("TEST_STORE_PORT"); value != 5432 {
+		t.Errorf("Expected 5432, got %d", value)
+	}
+	if value := store.GetBool("TEST_STORE_DEBUG"); !value {
+		t.Error("Expected true, got false")
+	}
+	if value := store.GetFloat("TEST_STORE_RATIO"); value != 0.5 {
+		t.Errorf("Expected 0.5, got %f", value)
+	}
+	if value := store.GetString("TEST_STORE_ENCODED"); value != "hello" {
+		t.Errorf("Expected 'hello', got '%s'", value)
+	}
+	if _, ok := store.LookupString("HOME"); ok {
+		t.Error("Expected the store not to see the process environment")
+	}
+
+	for _, key := range []string{"TEST_STORE_HOST", "TEST_STORE_PORT", "TEST_STORE_ENCODED"} {
+		if _, ok := os.LookupEnv(key); ok {
+			t.Errorf("Expected %s not to be set in the process environment", key)
+		}
+	}
+
+	_, err = store.GetIntOrError("TEST_STORE_BAD")
+	if !errors.Is(err, ErrInvalid) {
+		t.Fatalf("Expected ErrInvalid, got %v", err)
+	}
+	if strings.Contains(err.Error(), "not-a-number") {
+		t.Errorf("Expected the value to be redacted, got '%s'", err)
+	}
+
+	if _, err := LoadVaultStore(VaultOptions{Password: "secret", VaultFilePath: filepath.Join(t.TempDir(), "missing")}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestVaultKeySelection(t *testing.T) {
+	path := createTestVault(t, "secret", map[string]string{
+		"BILLING_API_KEY": "billing-key",
+		"BILLING_URL":     "https://billing",
+		"SHOP_API_KEY":    "shop-key",
+		"SHARED_REGION":   "eu",
+	})
+
+	tests := []struct {
+		name     string
+		options  VaultOptions
+		expected map[string]string
+	}{
+		{
+			name:     "allowlist",
+			options:  VaultOptions{Keys: []string{"SHARED_REGION", "SHOP_API_KEY"}},
+			expected: map[string]string{"SHARED_REGION": "eu", "SHOP_API_KEY": "shop-key"},
+		},
+		{
+			name:     "glob",
+			options:  VaultOptions{Keys: []string{"BILLING_*"}},
+			expected: map[string]string{"BILLING_API_KEY": "billing-key", "BILLING_URL": "https://billing"},
+		},
+		{
+			name:     "strip prefix",
+			options:  VaultOptions{StripPrefix: "BILLING_"},
+			expected: map[string]string{"API_KEY": "billing-key", "URL": "https://billing"},
+		},
+		{
+			name:     "add prefix",
+			options:  VaultOptions{Keys: []string{"SHOP_*"}, StripPrefix: "SHOP_", AddPrefix: "APP_"},
+			expected: map[string]string{"APP_API_KEY": "shop-key"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt.options.Password = "secret"
+		tt.options.VaultFilePath = path
+
+		keys, err := VaultList(tt.options)
+		if err != nil {
+			t.Errorf("%s: expected nil error, got '%s'", tt.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(keys, tt.expected) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, keys)
+		}
+	}
+
+	_, err := VaultList(VaultOptions{Password: "secret", VaultFilePath: path, Keys: []string{"["}})
+	if err == nil {
+		t.Error("Expected error for an invalid pattern, got nil")
+	}
+}
+
+func TestVaultRejectProtected(t *testing.T) {
+	path := createTestVault(t, "secret", map[string]string{
+		"TEST_PROTECTED_NAME": "value",
+		"SVC_PATH":            "/tmp/evil",
+		"SVC_LD_PRELOAD":      "/tmp/evil.so",
+	})
+
+	options := VaultOptions{Password: "secret", VaultFilePath: path, StripPrefix: "SVC_", RejectProtected: true}
+
+	_, err := LoadVaultWithOptions(options)
+	if !errors.Is(err, ErrInvalid) {
+		t.Fatalf("Expected ErrInvalid, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "'LD_PRELOAD'") {
+		t.Errorf("Expected the error to name the protected variable, got '%s'", err)
+	}
+
+	options.Keys = []string{"SVC_PATH"}
+	if _, err := LoadVaultWithOptions(options); !errors.Is(err, ErrInvalid) {
+		t.Errorf("Expected ErrInvalid for PATH, got %v", err)
+	}
+
+	// Without the prefix stripped the keys do not shadow anything
+	options = VaultOptions{Password: "secret", VaultFilePath: path, RejectProtected: true, Override: OverrideReplace}
+	_, err = LoadVaultWithOptions(options)
+	defer os.Unsetenv("SVC_PATH")
+	defer os.Unsetenv("SVC_LD_PRELOAD")
+	defer os.Unsetenv("TEST_PROTECTED_NAME")
+	if err != nil {
+		t.Errorf("Expected nil error, got '%s'", err)
+	}
+}
@@ -0,0 +1,144 @@
+package env
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// VaultsOptions configures LoadVaults.
+type VaultsOptions struct {
+	// Vaults lists the vaults to merge, lowest precedence first: a key
+	// defined in several vaults takes the value from the last one. Each
+	// vault has its own password and key selection; its Override field is
+	// ignored. Vaults sharing a password can share one PasswordSource,
+	// which is only asked once.
+	Vaults []VaultOptions
+
+	// Override controls what happens to variables that are already set
+	// in the process environment.
+	Override OverridePolicy
+}
+
+// VaultConflict describes a key that several vaults define with different
+// values.
+type VaultConflict struct {
+	// Key is the variable name, after any prefix renaming.
+	Key string
+
+	// Vaults lists the vaults defining the key, lowest precedence first.
+	Vaults []string
+
+	// Winner is the vault whose value was used.
+	Winner string
+}
+
+// VaultsReport describes the result of LoadVaults.
+type VaultsReport struct {
+	LoadReport
+
+	// Applied lists the vaults that were merged, lowest precedence first.
+	Applied []string
+
+	// Conflicts lists, sorted by key, the keys that several vaults define
+	// with different values.
+	Conflicts []VaultConflict
+}
+
+// LoadVaults merges several vaults, such as a shared vault followed by a
+// per-environment one, and loads the result into the process environment.
+//
+// Every vault is decrypted before anything is set, so a vault that cannot
+// be read leaves the environment untouched. Keys are applied in sorted
+// order.
+//
+// Parameters:
+//
+//	options: The vaults, lowest precedence first, and the override policy.
+//
+// Returns:
+//
+//	A report of the vaults merged, the conflicts between them and the keys
+//	set, replaced or skipped, and an error if loading fails. A
+//	*ConflictError is returned if OverrideFail found a conflict with the
+//	process environment, in which case nothing is set.
+func LoadVaults(options VaultsOptions) (*VaultsReport, error) {
+	if len(options.Vaults) == 0 {
+		return nil, errors.New("at least one vault is required")
+	}
+
+	merged, report, err := mergeVaults(options.Vaults, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	batch := valueBatch{
+		origin: strings.Join(report.Applied, ", "),
+		values: merged,
+	}
+
+	loadReport, err := applyBatches([]valueBatch{batch}, options.Override)
+	if err != nil {
+		return nil, err
+	}
+
+	report.LoadReport = *loadReport
+
+	return report, nil
+}
+
+// mergeVaults reads the vaults in order and merges them: a key defined in
+// several vaults takes the value of the last one. When process is not nil,
+// it is applied to the values of each vault before they are merged. The
+// report lists the vaults and the conflicts between them.
+func mergeVaults(vaults []VaultOptions, process func(origin string, values map[string]string) (map[string]string, error)) (map[string]string, *VaultsReport, error) {
+	report := &VaultsReport{}
+
+	merged := map[string]string{}
+	definedBy := map[string][]string{}
+	conflicting := map[string]bool{}
+
+	for i, vault := range vaults {
+		origin := vaultOrigin(vault, i)
+
+		keys, err := readVault(vault)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", origin, err)
+		}
+
+		if process != nil {
+			if keys, err = process(origin, keys); err != nil {
+				return nil, nil, err
+			}
+		}
+
+		for _, key := range sortedKeys(keys) {
+			if existing, ok := merged[key]; ok && existing != keys[key] {
+				conflicting[key] = true
+			}
+			merged[key] = keys[key]
+			definedBy[key] = append(definedBy[key], origin)
+		}
+
+		report.Applied = append(report.Applied, origin)
+	}
+
+	for _, key := range sortedKeys(conflicting) {
+		origins := definedBy[key]
+		report.Conflicts = append(report.Conflicts, VaultConflict{
+			Key:    key,
+			Vaults: origins,
+			Winner: origins[len(origins)-1],
+		})
+	}
+
+	return merged, report, nil
+}
+
+// vaultOrigin names the vault at index of a list in reports and errors.
+func vaultOrigin(options VaultOptions, index int) string {
+	if options.VaultFilePath != "" {
+		return options.VaultFilePath
+	}
+	return fmt.Sprintf("vault content #%d", index+1)
+}
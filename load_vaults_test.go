@@ -0,0 +1,138 @@
+package env
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoadVaults(t *testing.T) {
+	shared := createTestVault(t, "shared-password", map[string]string{
+		"TEST_VAULTS_REGION": "eu",
+		"TEST_VAULTS_HOST":   "shared-host",
+		"TEST_VAULTS_LEVEL":  "info",
+	})
+	production := createTestVault(t, "production-password", map[string]string{
+		"TEST_VAULTS_HOST":  "production-host",
+		"TEST_VAULTS_LEVEL": "info",
+		"TEST_VAULTS_TOKEN": "token",
+	})
+
+	keys := []string{"TEST_VAULTS_REGION", "TEST_VAULTS_HOST", "TEST_VAULTS_LEVEL", "TEST_VAULTS_TOKEN"}
+	for _, key := range keys {
+		defer os.Unsetenv(key)
+	}
+
+	report, err := LoadVaults(VaultsOptions{
+		Vaults: []VaultOptions{
+			{Password: "shared-password", VaultFilePath: shared},
+			{Password: "production-password", VaultFilePath: production},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Expected nil error, got '%s'", err)
+	}
+
+	expected := map[string]string{
+		"TEST_VAULTS_REGION": "eu",
+		"TEST_VAULTS_HOST":   "production-host",
+		"TEST_VAULTS_LEVEL":  "info",
+		"TEST_VAULTS_TOKEN":  "token",
+	}
+	for key, value := range expected {
+		if os.Getenv(key) != value {
+			t.Errorf("Expected %s to be '%s', got '%s'", key, value, os.Getenv(key))
+		}
+	}
+
+	if !reflect.DeepEqual(report.Applied, []string{shared, production}) {
+		t.Errorf("Expected the vaults in order, got %v", report.Applied)
+	}
+
+	conflicts := []VaultConflict{{Key: "TEST_VAULTS_HOST", Vaults: []string{shared, production}, Winner: production}}
+	if !reflect.DeepEqual(report.Conflicts, conflicts) {
+		t.Errorf("Expected %v, got %v", conflicts, report.Conflicts)
+	}
+
+	if !reflect.DeepEqual(report.Set, []string{"TEST_VAULTS_HOST", "TEST_VAULTS_LEVEL", "TEST_VAULTS_REGION", "TEST_VAULTS_TOKEN"}) {
+		t.Errorf("Expected every key to be set, got %v", report.Set)
+	}
+}
+
+func TestLoadVaultsOverride(t *testing.T) {
+	vault := createTestVault(t, "secret", map[string]string{"TEST_VAULTS_EXISTING": "from-vault"})
+
+	os.Setenv("TEST_VAULTS_EXISTING", "from-process")
+	defer os.Unsetenv("TEST_VAULTS_EXISTING")
+
+	options := VaultsOptions{Vaults: []VaultOptions{{Password: "secret", VaultFilePath: vault, Override: OverrideReplace}}}
+
+	report, err := LoadVaults(options)
+	if err != nil {
+		t.Fatalf("Expected nil error, got '%s'", err)
+	}
+	if os.Getenv("TEST_VAULTS_EXISTING") != "from-process" || !reflect.DeepEqual(report.Skipped, []string{"TEST_VAULTS_EXISTING"}) {
+		t.Errorf("Expected the per-vault override to be ignored, got '%s'", os.Getenv("TEST_VAULTS_EXISTING"))
+	}
+
+	options.Override = OverrideFail
+	var conflictErr *ConflictError
+	if _, err := LoadVaults(options); !errors.As(err, &conflictErr) {
+		t.Errorf("Expected *ConflictError, got %v", err)
+	}
+}
+
+func TestLoadVaultsFailure(t *testing.T) {
+	good := createTestVault(t, "secret", map[string]string{"TEST_VAULTS_PARTIAL": "value"})
+	missing := filepath.Join(t.TempDir(), "missing.vault")
+
+	_, err := LoadVaults(VaultsOptions{Vaults: []VaultOptions{
+		{Password: "secret", VaultFilePath: good},
+		{Password: "secret", VaultFilePath: missing},
+	}})
+	if !errors.Is(err, ErrNotFound) || !strings.Contains(err.Error(), missing) {
+		t.Errorf("Expected ErrNotFound naming the vault, got %v", err)
+	}
+	if _, ok := os.LookupEnv("TEST_VAULTS_PARTIAL"); ok {
+		t.Error("Expected nothing to be set when a vault fails")
+	}
+
+	_, err = LoadVaults(VaultsOptions{Vaults: []VaultOptions{{Password: "wrong", VaultFilePath: good}}})
+	if err == nil {
+		t.Error("Expected error for a wrong password, got nil")
+	}
+
+	if _, err := LoadVaults(VaultsOptions{}); err == nil {
+		t.Error("Expected error without vaults, got nil")
+	}
+}
+
+func TestLoadVaultsSharedPasswordSource(t *testing.T) {
+	first := createTestVault(t, "secret", map[string]string{"TEST_VAULTS_SHARED_A": "a"})
+	second := createTestVault(t, "secret", map[string]string{"TEST_VAULTS_SHARED_B": "b"})
+	defer os.Unsetenv("TEST_VAULTS_SHARED_A")
+	defer os.Unsetenv("TEST_VAULTS_SHARED_B")
+
+	os.Setenv("TEST_VAULTS_PASSWORD", "secret")
+	defer os.Unsetenv("TEST_VAULTS_PASSWORD")
+
+	sources := []PasswordSource{PasswordFromEnv("TEST_VAULTS_PASSWORD")}
+
+	_, err := LoadVaults(VaultsOptions{Vaults: []VaultOptions{
+		{PasswordSources: sources, VaultFilePath: first},
+		{PasswordSources: sources, VaultFilePath: second},
+	}})
+	if err != nil {
+		t.Fatalf("Expected nil error, got '%s'", err)
+	}
+
+	if os.Getenv("TEST_VAULTS_SHARED_A") != "a" || os.Getenv("TEST_VAULTS_SHARED_B") != "b" {
+		t.Error("Expected both vaults to be loaded")
+	}
+	if _, ok := os.LookupEnv("TEST_VAULTS_PASSWORD"); ok {
+		t.Error("Expected the password variable to be unset")
+	}
+}
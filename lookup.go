@@ -0,0 +1,272 @@
+package env
+
+import "time"
+
+// The Lookup functions mirror os.LookupEnv: they return the value and
+// whether it was found, instead of an error or a default. A value that is
+// set but cannot be parsed is reported as not found (or panics on a
+// strict Reader); use the matching OrError function to see why.
+//
+// By default an empty value counts as not set. A Reader created with
+// WithEmptyAsSet reports it as set, so LookupString returns ("", true).
+
+// LookupString retrieves the string value of an environment variable and
+// whether it is set and valid.
+func LookupString(key string) (string, bool) {
+	return Default().LookupString(key)
+}
+
+// LookupBool retrieves the boolean value of an environment variable and
+// whether it is set and valid.
+func LookupBool(key string) (bool, bool) {
+	return Default().LookupBool(key)
+}
+
+// LookupInt retrieves the int value of an environment variable and
+// whether it is set and valid.
+func LookupInt(key string) (int, bool) {
+	return Default().LookupInt(key)
+}
+
+// LookupFloat retrieves the float64 value of an environment variable and
+// whether it is set and valid.
+func LookupFloat(key string) (float64, bool) {
+	return Default().LookupFloat(key)
+}
+
+// LookupDuration retrieves the duration value of an environment variable and
+// whether it is set and valid.
+func LookupDuration(key string) (time.Duration, bool) {
+	return Default().LookupDuration(key)
+}
+
+// LookupInt8 retrieves the int8 value of an environment variable and
+// whether it is set and valid.
+func LookupInt8(key string) (int8, bool) {
+	return Default().LookupInt8(key)
+}
+
+// LookupInt16 retrieves the int16 value of an environment variable and
+// whether it is set and valid.
+func LookupInt16(key string) (int16, bool) {
+	return Default().LookupInt16(key)
+}
+
+// LookupInt32 retrieves the int32 value of an environment variable and
+// whether it is set and valid.
+func LookupInt32(key string) (int32, bool) {
+	return Default().LookupInt32(key)
+}
+
+// LookupInt64 retrieves the int64 value of an environment variable and
+// whether it is set and valid.
+func LookupInt64(key string) (int64, bool) {
+	return Default().LookupInt64(key)
+}
+
+// LookupUint retrieves the uint value of an environment variable and
+// whether it is set and valid.
+func LookupUint(key string) (uint, bool) {
+	return Default().LookupUint(key)
+}
+
+// LookupUint8 retrieves the uint8 value of an environment variable and
+// whether it is set and valid.
+func LookupUint8(key string) (uint8, bool) {
+	return Default().LookupUint8(key)
+}
+
+// LookupUint16 retrieves the uint16 value of an environment variable and
+// whether it is set and valid.
+func LookupUint16(key string) (uint16, bool) {
+	return Default().LookupUint16(key)
+}
+
+// LookupUint32 retrieves the uint32 value of an environment variable and
+// whether it is set and valid.
+func LookupUint32(key string) (uint32, bool) {
+	return Default().LookupUint32(key)
+}
+
+// LookupUint64 retrieves the uint64 value of an environment variable and
+// whether it is set and valid.
+func LookupUint64(key string) (uint64, bool) {
+	return Default().LookupUint64(key)
+}
+
+// LookupStringSlice retrieves the []string value of an environment variable and
+// whether it is set and valid.
+func LookupStringSlice(key string, options ...ListOption) ([]string, bool) {
+	return Default().LookupStringSlice(key, options...)
+}
+
+// LookupIntSlice retrieves the []int value of an environment variable and
+// whether it is set and valid.
+func LookupIntSlice(key string, options ...ListOption) ([]int, bool) {
+	return Default().LookupIntSlice(key, options...)
+}
+
+// LookupFloatSlice retrieves the []float64 value of an environment variable and
+// whether it is set and valid.
+func LookupFloatSlice(key string, options ...ListOption) ([]float64, bool) {
+	return Default().LookupFloatSlice(key, options...)
+}
+
+// LookupBoolSlice retrieves the []bool value of an environment variable and
+// whether it is set and valid.
+func LookupBoolSlice(key string, options ...ListOption) ([]bool, bool) {
+	return Default().LookupBoolSlice(key, options...)
+}
+
+// LookupStringMap retrieves the map[string]string value of an environment variable and
+// whether it is set and valid.
+func LookupStringMap(key string, options ...ListOption) (map[string]string, bool) {
+	return Default().LookupStringMap(key, options...)
+}
+
+// Lookup retrieves the value of an environment variable as T and whether
+// it is set and valid. See GetOrError for the supported types.
+func Lookup[T any](key string) (T, bool) {
+	value, err := GetOrError[T](key)
+	return value, Default().accept(err)
+}
+
+// LookupMap retrieves the map value of an environment variable and whether
+// it is set and valid. See GetMapOrError for the supported value types.
+func LookupMap[V any](key string, options ...ListOption) (map[string]V, bool) {
+	value, err := GetMapOrError[V](key, options...)
+	return value, Default().accept(err)
+}
+
+// LookupString retrieves the string value of key and whether it is set
+// and valid.
+func (r *Reader) LookupString(key string) (string, bool) {
+	value, err := r.GetStringOrError(key)
+	return value, r.accept(err)
+}
+
+// LookupBool retrieves the boolean value of key and whether it is set
+// and valid.
+func (r *Reader) LookupBool(key string) (bool, bool) {
+	value, err := r.GetBoolOrError(key)
+	return value, r.accept(err)
+}
+
+// LookupInt retrieves the int value of key and whether it is set
+// and valid.
+func (r *Reader) LookupInt(key string) (int, bool) {
+	value, err := r.GetIntOrError(key)
+	return value, r.accept(err)
+}
+
+// LookupFloat retrieves the float64 value of key and whether it is set
+// and valid.
+func (r *Reader) LookupFloat(key string) (float64, bool) {
+	value, err := r.GetFloatOrError(key)
+	return value, r.accept(err)
+}
+
+// LookupDuration retrieves the duration value of key and whether it is set
+// and valid.
+func (r *Reader) LookupDuration(key string) (time.Duration, bool) {
+	value, err := r.GetDurationOrError(key)
+	return value, r.accept(err)
+}
+
+// LookupInt8 retrieves the int8 value of key and whether it is set
+// and valid.
+func (r *Reader) LookupInt8(key string) (int8, bool) {
+	value, err := r.GetInt8OrError(key)
+	return value, r.accept(err)
+}
+
+// LookupInt16 retrieves the int16 value of key and whether it is set
+// and valid.
+func (r *Reader) LookupInt16(key string) (int16, bool) {
+	value, err := r.GetInt16OrError(key)
+	return value, r.accept(err)
+}
+
+// LookupInt32 retrieves the int32 value of key and whether it is set
+// and valid.
+func (r *Reader) LookupInt32(key string) (int32, bool) {
+	value, err := r.GetInt32OrError(key)
+	return value, r.accept(err)
+}
+
+// LookupInt64 retrieves the int64 value of key and whether it is set
+// and valid.
+func (r *Reader) LookupInt64(key string) (int64, bool) {
+	value, err := r.GetInt64OrError(key)
+	return value, r.accept(err)
+}
+
+// LookupUint retrieves the uint value of key and whether it is set
+// and valid.
+func (r *Reader) LookupUint(key string) (uint, bool) {
+	value, err := r.GetUintOrError(key)
+	return value, r.accept(err)
+}
+
+// LookupUint8 retrieves the uint8 value of key and whether it is set
+// and valid.
+func (r *Reader) LookupUint8(key string) (uint8, bool) {
+	value, err := r.GetUint8OrError(key)
+	return value, r.accept(err)
+}
+
+// LookupUint16 retrieves the uint16 value of key and whether it is set
+// and valid.
+func (r *Reader) LookupUint16(key string) (uint16, bool) {
+	value, err := r.GetUint16OrError(key)
+	return value, r.accept(err)
+}
+
+// LookupUint32 retrieves the uint32 value of key and whether it is set
+// and valid.
+func (r *Reader) LookupUint32(key string) (uint32, bool) {
+	value, err := r.GetUint32OrError(key)
+	return value, r.accept(err)
+}
+
+// LookupUint64 retrieves the uint64 value of key and whether it is set
+// and valid.
+func (r *Reader) LookupUint64(key string) (uint64, bool) {
+	value, err := r.GetUint64OrError(key)
+	return value, r.accept(err)
+}
+
+// LookupStringSlice retrieves the []string value of key and whether it is set
+// and valid.
+func (r *Reader) LookupStringSlice(key string, options ...ListOption) ([]string, bool) {
+	value, err := r.GetStringSliceOrError(key, options...)
+	return value, r.accept(err)
+}
+
+// LookupIntSlice retrieves the []int value of key and whether it is set
+// and valid.
+func (r *Reader) LookupIntSlice(key string, options ...ListOption) ([]int, bool) {
+	value, err := r.GetIntSliceOrError(key, options...)
+	return value, r.accept(err)
+}
+
+// LookupFloatSlice retrieves the []float64 value of key and whether it is set
+// and valid.
+func (r *Reader) LookupFloatSlice(key string, options ...ListOption) ([]float64, bool) {
+	value, err := r.GetFloatSliceOrError(key, options...)
+	return value, r.accept(err)
+}
+
+// LookupBoolSlice retrieves the []bool value of key and whether it is set
+// and valid.
+func (r *Reader) LookupBoolSlice(key string, options ...ListOption) ([]bool, bool) {
+	value, err := r.GetBoolSliceOrError(key, options...)
+	return value, r.accept(err)
+}
+
+// LookupStringMap retrieves the map[string]string value of key and whether it is set
+// and valid.
+func (r *Reader) LookupStringMap(key string, options ...ListOption) (map[string]string, bool) {
+	value, err := r.GetStringMapOrError(key, options...)
+	return value, r.accept(err)
+}
@@ -0,0 +1,125 @@
+package env
+
+import (
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestLookupString(t *testing.T) {
+	os.Setenv("TEST_LOOKUP_STRING", "value")
+	os.Setenv("TEST_LOOKUP_EMPTY", "")
+	os.Unsetenv("TEST_LOOKUP_MISSING")
+	defer os.Unsetenv("TEST_LOOKUP_STRING")
+	defer os.Unsetenv("TEST_LOOKUP_EMPTY")
+
+	value, ok := LookupString("TEST_LOOKUP_STRING")
+	if !ok || value != "value" {
+		t.Errorf("Expected ('value', true), got ('%s', %v)", value, ok)
+	}
+
+	value, ok = LookupString("TEST_LOOKUP_EMPTY")
+	if ok || value != "" {
+		t.Errorf("Expected ('', false), got ('%s', %v)", value, ok)
+	}
+
+	value, ok = LookupString("TEST_LOOKUP_MISSING")
+	if ok || value != "" {
+		t.Errorf("Expected ('', false), got ('%s', %v)", value, ok)
+	}
+}
+
+func TestLookupTyped(t *testing.T) {
+	reader := New(MapSource{
+		"PORT":    "8080",
+		"DEBUG":   "yes",
+		"RATIO":   "0.5",
+		"TIMEOUT": "1m",
+		"MASK":    "256",
+		"HOSTS":   "a,b",
+		"LABELS":  "env=prod",
+		"BROKEN":  "abc",
+	})
+
+	if value, ok := reader.LookupInt("PORT"); !ok || value != 8080 {
+		t.Errorf("Expected (8080, true), got (%d, %v)", value, ok)
+	}
+	if value, ok := reader.LookupBool("DEBUG"); !ok || value != true {
+		t.Errorf("Expected (true, true), got (%v, %v)", value, ok)
+	}
+	if value, ok := reader.LookupFloat("RATIO"); !ok || value != 0.5 {
+		t.Errorf("Expected (0.5, true), got (%f, %v)", value, ok)
+	}
+	if value, ok := reader.LookupDuration("TIMEOUT"); !ok || value != time.Minute {
+		t.Errorf("Expected (1m, true), got (%v, %v)", value, ok)
+	}
+	if value, ok := reader.LookupUint8("MASK"); ok || value != 0 {
+		t.Errorf("Expected (0, false) for an out of range value, got (%d, %v)", value, ok)
+	}
+	if value, ok := reader.LookupStringSlice("HOSTS"); !ok || !reflect.DeepEqual(value, []string{"a", "b"}) {
+		t.Errorf("Expected ([a b], true), got (%v, %v)", value, ok)
+	}
+	if value, ok := reader.LookupStringMap("LABELS"); !ok || !reflect.DeepEqual(value, map[string]string{"env": "prod"}) {
+		t.Errorf("Expected (map[env:prod], true), got (%v, %v)", value, ok)
+	}
+	if value, ok := reader.LookupInt("BROKEN"); ok || value != 0 {
+		t.Errorf("Expected (0, false) for a malformed value, got (%d, %v)", value, ok)
+	}
+	if value, ok := reader.LookupInt("MISSING"); ok || value != 0 {
+		t.Errorf("Expected (0, false) for a missing value, got (%d, %v)", value, ok)
+	}
+}
+
+func TestLookupGeneric(t *testing.T) {
+	os.Setenv("TEST_LOOKUP_GENERIC", "42")
+	defer os.Unsetenv("TEST_LOOKUP_GENERIC")
+
+	if value, ok := Lookup[uint16]("TEST_LOOKUP_GENERIC"); !ok || value != 42 {
+		t.Errorf("Expected (42, true), got (%d, %v)", value, ok)
+	}
+	if _, ok := Lookup[uint16]("TEST_LOOKUP_GENERIC_MISSING"); ok {
+		t.Error("Expected false for a missing value, got true")
+	}
+}
+
+func TestWithEmptyAsSet(t *testing.T) {
+	source := MapSource{"FEATURE": ""}
+
+	reader := New(source)
+	if value := reader.GetStringOrDefault("FEATURE", "on"); value != "on" {
+		t.Errorf("Expected 'on', got '%s'", value)
+	}
+
+	reader = New(source, WithEmptyAsSet())
+
+	if value := reader.GetStringOrDefault("FEATURE", "on"); value != "" {
+		t.Errorf("Expected '', got '%s'", value)
+	}
+	if value, err := reader.GetStringOrError("FEATURE"); err != nil || value != "" {
+		t.Errorf("Expected ('', nil), got ('%s', %v)", value, err)
+	}
+	if value, ok := reader.LookupString("FEATURE"); !ok || value != "" {
+		t.Errorf("Expected ('', true), got ('%s', %v)", value, ok)
+	}
+	if _, ok := reader.LookupString("MISSING"); ok {
+		t.Error("Expected false for a missing value, got true")
+	}
+	if _, ok := reader.LookupInt("FEATURE"); ok {
+		t.Error("Expected false for an empty integer, got true")
+	}
+}
+
+func TestWithEmptyAsSetBind(t *testing.T) {
+	type config struct {
+		Prefix string `env:"PREFIX" default:"app"`
+	}
+
+	cfg := config{}
+	if err := New(MapSource{"PREFIX": ""}, WithEmptyAsSet()).Bind(&cfg); err != nil {
+		t.Fatalf("Expected nil error, got '%s'", err)
+	}
+	if cfg.Prefix != "" {
+		t.Errorf("Expected '', got '%s'", cfg.Prefix)
+	}
+}
@@ -0,0 +1,150 @@
+package env
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// GetStringMap retrieves the key=value list value of an environment variable as a map.
+// It returns nil if the key is not found or the value is malformed.
+func GetStringMap(key string, options ...ListOption) map[string]string {
+	return Default().GetStringMap(key, options...)
+}
+
+// GetStringMapOrDefault retrieves the key=value list value of an environment variable with a default.
+func GetStringMapOrDefault(key string, defaultValue map[string]string, options ...ListOption) map[string]string {
+	return Default().GetStringMapOrDefault(key, defaultValue, options...)
+}
+
+// GetStringMapOrError retrieves the key=value list value of an environment variable,
+// returning an error if the key is not found or a pair is malformed.
+func GetStringMapOrError(key string, options ...ListOption) (map[string]string, error) {
+	return Default().GetStringMapOrError(key, options...)
+}
+
+// GetStringMapOrPanic retrieves the key=value list value of an environment variable,
+// panicking if not set or on parsing error.
+func GetStringMapOrPanic(key string, options ...ListOption) map[string]string {
+	return Default().GetStringMapOrPanic(key, options...)
+}
+
+// GetStringMap retrieves the key=value list value of key as a map.
+// It returns nil if the key is not found or the value is malformed.
+func (r *Reader) GetStringMap(key string, options ...ListOption) map[string]string {
+	value, err := r.GetStringMapOrError(key, options...)
+	return orDefault(r, value, err, nil)
+}
+
+// GetStringMapOrDefault retrieves the key=value list value of key with a default.
+func (r *Reader) GetStringMapOrDefault(key string, defaultValue map[string]string, options ...ListOption) map[string]string {
+	value, err := r.GetStringMapOrError(key, options...)
+	return orDefault(r, value, err, defaultValue)
+}
+
+// GetStringMapOrError retrieves the key=value list value of key,
+// returning an error if the key is not found or a pair is malformed.
+func (r *Reader) GetStringMapOrError(key string, options ...ListOption) (map[string]string, error) {
+	return readMap(r, key, parseListString, options)
+}
+
+// GetStringMapOrPanic retrieves the key=value list value of key,
+// panicking if not set or on parsing error.
+func (r *Reader) GetStringMapOrPanic(key string, options ...ListOption) map[string]string {
+	value, err := r.GetStringMapOrError(key, options...)
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
+// GetMap retrieves the key=value list value of an environment variable as a
+// map whose values are parsed as V using the parser registered for V
+// (see RegisterParser).
+// It returns nil if the key is not found or the value is malformed.
+func GetMap[V any](key string, options ...ListOption) map[string]V {
+	value, err := GetMapOrError[V](key, options...)
+	return orDefault(Default(), value, err, nil)
+}
+
+// GetMapOrDefault retrieves the typed key=value list value of an environment variable with a default.
+func GetMapOrDefault[V any](key string, defaultValue map[string]V, options ...ListOption) map[string]V {
+	value, err := GetMapOrError[V](key, options...)
+	return orDefault(Default(), value, err, defaultValue)
+}
+
+// GetMapOrError retrieves the typed key=value list value of an environment variable,
+// returning an error if the key is not found, a pair is malformed or a
+// value cannot be parsed as V.
+func GetMapOrError[V any](key string, options ...ListOption) (map[string]V, error) {
+	r := Default()
+
+	parse, ok := lookupParser[V]()
+	if !ok {
+		return nil, fmt.Errorf("environment variable '%s': no parser registered for type %s", key, reflect.TypeFor[V]())
+	}
+
+	return readMap(r, key, func(key string, value string) (V, error) {
+		return parse(r, key, value)
+	}, options)
+}
+
+// GetMapOrPanic retrieves the typed key=value list value of an environment variable,
+// panicking if not set or on parsing error.
+func GetMapOrPanic[V any](key string, options ...ListOption) map[string]V {
+	value, err := GetMapOrError[V](key, options...)
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
+// readMap splits the value of key into pairs, splits each pair into a key
+// and a value, and parses the value. Errors name the offending pair by
+// its position in the value, counting skipped empty pairs, e.g.
+// 'LIMITS[2]' for "b" in "a=1,,b".
+func readMap[V any](r *Reader, key string, parse func(key string, value string) (V, error), options []ListOption) (map[string]V, error) {
+	config := newListConfig(options)
+	if config.keyValueSeparator == "" {
+		return nil, fmt.Errorf("environment variable '%s': key/value separator cannot be empty", key)
+	}
+
+	return readValue(r, key, func(key string, valueStr string) (map[string]V, error) {
+		pairs, indexes, err := splitList(key, valueStr, config)
+		if err != nil {
+			return nil, err
+		}
+
+		values := make(map[string]V, len(pairs))
+
+		for i, pair := range pairs {
+			pairKey := fmt.Sprintf("%s[%d]", key, indexes[i])
+
+			name, value, found := strings.Cut(pair, config.keyValueSeparator)
+			if !found {
+				return nil, invalidError(pairKey, pair, "key=value pair", fmt.Errorf("missing the '%s' separator", config.keyValueSeparator))
+			}
+
+			name = trimListElement(name, config)
+			value = trimListElement(value, config)
+
+			if name == "" {
+				return nil, invalidError(pairKey, pair, "key=value pair", errors.New("empty key"))
+			}
+
+			if _, exists := values[name]; exists {
+				return nil, invalidError(pairKey, pair, "key=value pair", fmt.Errorf("duplicate key '%s'", name))
+			}
+
+			parsed, err := parse(pairKey, value)
+			if err != nil {
+				return nil, err
+			}
+
+			values[name] = parsed
+		}
+
+		return values, nil
+	})
+}
@@ -0,0 +1,123 @@
+package env
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGetStringMap(t *testing.T) {
+	os.Setenv("TEST_MAP", "team=core, tier=1")
+	value := GetStringMap("TEST_MAP")
+	if !reflect.DeepEqual(value, map[string]string{"team": "core", "tier": "1"}) {
+		t.Errorf("Expected map[team:core tier:1], got %v", value)
+	}
+	os.Unsetenv("TEST_MAP")
+
+	value = GetStringMap("NON_EXISTENT")
+	if value != nil {
+		t.Errorf("Expected nil, got %v", value)
+	}
+}
+
+func TestGetStringMapOrDefault(t *testing.T) {
+	defaultValue := map[string]string{"team": "default"}
+	value := GetStringMapOrDefault("NON_EXISTENT", defaultValue)
+	if !reflect.DeepEqual(value, defaultValue) {
+		t.Errorf("Expected %v, got %v", defaultValue, value)
+	}
+}
+
+func TestGetStringMapOrError(t *testing.T) {
+	reader := New(MapSource{
+		"CUSTOM":    "a:1;b:2",
+		"QUOTED":    `"query=a=1,b=2",name=x`,
+		"MISSING":   "a=1,b,c=3",
+		"EMPTY_KEY": "a=1,=2",
+		"DUPLICATE": "a=1,b=2,a=3",
+		"GAP":       "a=1,,b",
+	})
+
+	value, err := reader.GetStringMapOrError("CUSTOM", WithSeparator(";"), WithKeyValueSeparator(":"))
+	if err != nil {
+		t.Errorf("Expected nil error, got '%s'", err)
+	}
+	if !reflect.DeepEqual(value, map[string]string{"a": "1", "b": "2"}) {
+		t.Errorf("Expected map[a:1 b:2], got %v", value)
+	}
+
+	value, err = reader.GetStringMapOrError("QUOTED")
+	if err != nil {
+		t.Errorf("Expected nil error, got '%s'", err)
+	}
+	if value["query"] != "a=1,b=2" {
+		t.Errorf("Expected 'a=1,b=2', got '%s'", value["query"])
+	}
+
+	errorCases := map[string]string{
+		"MISSING":   "MISSING[1]",
+		"EMPTY_KEY": "EMPTY_KEY[1]",
+		"DUPLICATE": "DUPLICATE[2]",
+		"GAP":       "GAP[2]",
+	}
+	for key, position := range errorCases {
+		_, err := reader.GetStringMapOrError(key)
+		if err == nil {
+			t.Errorf("%s: expected error, got nil", key)
+			continue
+		}
+		if !strings.Contains(err.Error(), position) {
+			t.Errorf("%s: expected error to name '%s', got '%s'", key, position, err)
+		}
+	}
+
+	_, err = reader.GetStringMapOrError("NON_EXISTENT")
+	if err == nil {
+		t.Error("Expected error, got nil")
+	}
+}
+
+func TestGetMap(t *testing.T) {
+	os.Setenv("TEST_MAP_INT", "tenant-a=100, tenant-b=250")
+	os.Setenv("TEST_MAP_DURATION", "fast=100ms,slow=1m")
+	os.Setenv("TEST_MAP_INVALID", "tenant-a=100,tenant-b=many")
+	defer os.Unsetenv("TEST_MAP_INT")
+	defer os.Unsetenv("TEST_MAP_DURATION")
+	defer os.Unsetenv("TEST_MAP_INVALID")
+
+	limits := GetMap[int]("TEST_MAP_INT")
+	if !reflect.DeepEqual(limits, map[string]int{"tenant-a": 100, "tenant-b": 250}) {
+		t.Errorf("Expected map[tenant-a:100 tenant-b:250], got %v", limits)
+	}
+
+	timeouts := GetMap[time.Duration]("TEST_MAP_DURATION")
+	if timeouts["fast"] != 100*time.Millisecond || timeouts["slow"] != time.Minute {
+		t.Errorf("Expected fast=100ms slow=1m, got %v", timeouts)
+	}
+
+	_, err := GetMapOrError[int]("TEST_MAP_INVALID")
+	if err == nil || !strings.Contains(err.Error(), "TEST_MAP_INVALID[1]") {
+		t.Errorf("Expected error naming TEST_MAP_INVALID[1], got '%v'", err)
+	}
+
+	_, err = GetMapOrError[complex128]("TEST_MAP_INT")
+	if err == nil {
+		t.Error("Expected error for unsupported type, got nil")
+	}
+
+	defaultValue := map[string]int{"x": 1}
+	if value := GetMapOrDefault("NON_EXISTENT", defaultValue); !reflect.DeepEqual(value, defaultValue) {
+		t.Errorf("Expected %v, got %v", defaultValue, value)
+	}
+}
+
+func TestGetMapOrPanic(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("The code did not panic")
+		}
+	}()
+	GetMapOrPanic[int]("NON_EXISTENT")
+}
@@ -0,0 +1,174 @@
+package env
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// OverridePolicy controls what a loader does with a variable that is
+// already set in the process environment.
+type OverridePolicy int
+
+const (
+	// OverrideKeep keeps the existing value and skips the loaded one.
+	OverrideKeep OverridePolicy = iota
+
+	// OverrideReplace replaces the existing value with the loaded one.
+	OverrideReplace
+
+	// OverrideFail aborts the load, without setting anything, if a loaded
+	// value differs from the existing one.
+	OverrideFail
+)
+
+// String returns the name of the policy.
+func (p OverridePolicy) String() string {
+	switch p {
+	case OverrideKeep:
+		return "keep"
+	case OverrideReplace:
+		return "replace"
+	case OverrideFail:
+		return "fail"
+	default:
+		return fmt.Sprintf("OverridePolicy(%d)", int(p))
+	}
+}
+
+// LoadReport lists what a loader did with each key, in sorted order. Each
+// key is listed once, comparing the environment before and after the load.
+type LoadReport struct {
+	// Set lists keys that were not set before and have been set.
+	Set []string
+
+	// Replaced lists keys whose existing value was overwritten.
+	Replaced []string
+
+	// Skipped lists keys that were already set and kept their value, either
+	// because the policy kept it or because the loaded value was equal.
+	Skipped []string
+}
+
+// ConflictError is returned by loaders using OverrideFail when loaded
+// values differ from values that are already set.
+type ConflictError struct {
+	// Origin is the file or vault that caused the conflict.
+	Origin string
+
+	// Keys lists the conflicting keys in sorted order.
+	Keys []string
+}
+
+// Error returns the error message.
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("environment variables already set with different values (from %s): %s", e.Origin, strings.Join(e.Keys, ", "))
+}
+
+// valueBatch is a set of loaded values together with where they came from.
+type valueBatch struct {
+	origin string
+	values map[string]string
+}
+
+// applyBatches applies the batches to the process environment in order,
+// following the override policy. With OverrideFail nothing is set if any
+// conflict is found.
+func applyBatches(batches []valueBatch, policy OverridePolicy) (*LoadReport, error) {
+	staged, report, err := stageBatches(batches, policy, os.LookupEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, key := range sortedKeys(staged) {
+		if err := os.Setenv(key, staged[key]); err != nil {
+			return nil, err
+		}
+	}
+
+	return report, nil
+}
+
+// stageBatches resolves the batches in order against the environment
+// described by lookup, following the override policy. It returns the
+// values to set without setting them. The report compares the outcome of
+// the whole load with the environment before it, so each key is listed
+// once.
+func stageBatches(batches []valueBatch, policy OverridePolicy, lookup func(key string) (string, bool)) (map[string]string, *LoadReport, error) {
+	staged := map[string]string{}
+	loaded := map[string]bool{}
+
+	current := func(key string) (string, bool) {
+		if value, ok := staged[key]; ok {
+			return value, true
+		}
+		return lookup(key)
+	}
+
+	for _, batch := range batches {
+		var conflicts []string
+
+		for _, key := range sortedKeys(batch.values) {
+			value := batch.values[key]
+			existing, exists := current(key)
+			loaded[key] = true
+
+			switch {
+			case !exists:
+				staged[key] = value
+			case existing == value, policy == OverrideKeep:
+				// Keep the current value
+			case policy == OverrideReplace:
+				staged[key] = value
+			case policy == OverrideFail:
+				conflicts = append(conflicts, key)
+			default:
+				return nil, nil, fmt.Errorf("unknown override policy: %s", policy)
+			}
+		}
+
+		if len(conflicts) > 0 {
+			return nil, nil, &ConflictError{Origin: batch.origin, Keys: conflicts}
+		}
+	}
+
+	set := map[string]bool{}
+	replaced := map[string]bool{}
+	skipped := map[string]bool{}
+
+	for key := range loaded {
+		value, isStaged := staged[key]
+		previous, existed := lookup(key)
+
+		switch {
+		case !isStaged:
+			skipped[key] = true
+		case !existed:
+			set[key] = true
+		case previous == value:
+			skipped[key] = true
+			delete(staged, key)
+		default:
+			replaced[key] = true
+		}
+	}
+
+	report := &LoadReport{
+		Set:      sortedKeys(set),
+		Replaced: sortedKeys(replaced),
+		Skipped:  sortedKeys(skipped),
+	}
+
+	return staged, report, nil
+}
+
+// sortedKeys returns the keys of m in sorted order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,144 @@
+package env
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestApplyBatches(t *testing.T) {
+	os.Setenv("TEST_OVERRIDE_EXISTING", "original")
+	os.Setenv("TEST_OVERRIDE_SAME", "same")
+	defer os.Unsetenv("TEST_OVERRIDE_EXISTING")
+	defer os.Unsetenv("TEST_OVERRIDE_SAME")
+	defer os.Unsetenv("TEST_OVERRIDE_NEW")
+
+	batches := []valueBatch{
+		{origin: "first", values: map[string]string{
+			"TEST_OVERRIDE_EXISTING": "first",
+			"TEST_OVERRIDE_SAME":     "same",
+			"TEST_OVERRIDE_NEW":      "first",
+		}},
+		{origin: "second", values: map[string]string{
+			"TEST_OVERRIDE_NEW": "second",
+		}},
+	}
+
+	report, err := applyBatches(batches, OverrideReplace)
+	if err != nil {
+		t.Fatalf("Expected nil error, got '%s'", err)
+	}
+
+	if os.Getenv("TEST_OVERRIDE_EXISTING") != "first" {
+		t.Errorf("Expected 'first', got '%s'", os.Getenv("TEST_OVERRIDE_EXISTING"))
+	}
+	if os.Getenv("TEST_OVERRIDE_NEW") != "second" {
+		t.Errorf("Expected 'second', got '%s'", os.Getenv("TEST_OVERRIDE_NEW"))
+	}
+	if !reflect.DeepEqual(report.Set, []string{"TEST_OVERRIDE_NEW"}) {
+		t.Errorf("Expected Set [TEST_OVERRIDE_NEW], got %v", report.Set)
+	}
+	if !reflect.DeepEqual(report.Replaced, []string{"TEST_OVERRIDE_EXISTING"}) {
+		t.Errorf("Expected Replaced [TEST_OVERRIDE_EXISTING], got %v", report.Replaced)
+	}
+	if !reflect.DeepEqual(report.Skipped, []string{"TEST_OVERRIDE_SAME"}) {
+		t.Errorf("Expected Skipped [TEST_OVERRIDE_SAME], got %v", report.Skipped)
+	}
+}
+
+func TestApplyBatchesKeep(t *testing.T) {
+	os.Setenv("TEST_OVERRIDE_EXISTING", "original")
+	defer os.Unsetenv("TEST_OVERRIDE_EXISTING")
+	defer os.Unsetenv("TEST_OVERRIDE_NEW")
+
+	batches := []valueBatch{
+		{origin: "first", values: map[string]string{"TEST_OVERRIDE_NEW": "first"}},
+		{origin: "second", values: map[string]string{
+			"TEST_OVERRIDE_EXISTING": "second",
+			"TEST_OVERRIDE_NEW":      "second",
+		}},
+	}
+
+	report, err := applyBatches(batches, OverrideKeep)
+	if err != nil {
+		t.Fatalf("Expected nil error, got '%s'", err)
+	}
+
+	if os.Getenv("TEST_OVERRIDE_EXISTING") != "original" {
+		t.Errorf("Expected 'original', got '%s'", os.Getenv("TEST_OVERRIDE_EXISTING"))
+	}
+	if os.Getenv("TEST_OVERRIDE_NEW") != "first" {
+		t.Errorf("Expected 'first', got '%s'", os.Getenv("TEST_OVERRIDE_NEW"))
+	}
+	if !reflect.DeepEqual(report.Set, []string{"TEST_OVERRIDE_NEW"}) {
+		t.Errorf("Expected Set [TEST_OVERRIDE_NEW], got %v", report.Set)
+	}
+	if !reflect.DeepEqual(report.Skipped, []string{"TEST_OVERRIDE_EXISTING"}) {
+		t.Errorf("Expected Skipped [TEST_OVERRIDE_EXISTING], got %v", report.Skipped)
+	}
+}
+
+func TestApplyBatchesReport(t *testing.T) {
+	os.Setenv("TEST_OVERRIDE_AGREED", "0")
+	os.Setenv("TEST_OVERRIDE_RESTORED", "0")
+	defer os.Unsetenv("TEST_OVERRIDE_AGREED")
+	defer os.Unsetenv("TEST_OVERRIDE_RESTORED")
+
+	batches := []valueBatch{
+		{origin: "a", values: map[string]string{
+			"TEST_OVERRIDE_AGREED":   "1",
+			"TEST_OVERRIDE_RESTORED": "1",
+		}},
+		{origin: "b", values: map[string]string{
+			"TEST_OVERRIDE_AGREED":   "1",
+			"TEST_OVERRIDE_RESTORED": "0",
+		}},
+	}
+
+	report, err := applyBatches(batches, OverrideReplace)
+	if err != nil {
+		t.Fatalf("Expected nil error, got '%s'", err)
+	}
+
+	if os.Getenv("TEST_OVERRIDE_AGREED") != "1" {
+		t.Errorf("Expected '1', got '%s'", os.Getenv("TEST_OVERRIDE_AGREED"))
+	}
+	if os.Getenv("TEST_OVERRIDE_RESTORED") != "0" {
+		t.Errorf("Expected '0', got '%s'", os.Getenv("TEST_OVERRIDE_RESTORED"))
+	}
+	if !reflect.DeepEqual(report.Replaced, []string{"TEST_OVERRIDE_AGREED"}) {
+		t.Errorf("Expected Replaced [TEST_OVERRIDE_AGREED], got %v", report.Replaced)
+	}
+	if !reflect.DeepEqual(report.Skipped, []string{"TEST_OVERRIDE_RESTORED"}) {
+		t.Errorf("Expected Skipped [TEST_OVERRIDE_RESTORED], got %v", report.Skipped)
+	}
+}
+
+func TestApplyBatchesFail(t *testing.T) {
+	os.Setenv("TEST_OVERRIDE_EXISTING", "original")
+	defer os.Unsetenv("TEST_OVERRIDE_EXISTING")
+
+	batches := []valueBatch{
+		{origin: "first", values: map[string]string{
+			"TEST_OVERRIDE_EXISTING": "changed",
+			"TEST_OVERRIDE_NEW":      "new",
+		}},
+	}
+
+	_, err := applyBatches(batches, OverrideFail)
+
+	var conflictErr *ConflictError
+	if !errors.As(err, &conflictErr) {
+		t.Fatalf("Expected *ConflictError, got %v", err)
+	}
+	if conflictErr.Origin != "first" {
+		t.Errorf("Expected origin 'first', got '%s'", conflictErr.Origin)
+	}
+	if !reflect.DeepEqual(conflictErr.Keys, []string{"TEST_OVERRIDE_EXISTING"}) {
+		t.Errorf("Expected conflict on TEST_OVERRIDE_EXISTING, got %v", conflictErr.Keys)
+	}
+	if _, exists := os.LookupEnv("TEST_OVERRIDE_NEW"); exists {
+		t.Error("Expected nothing to be set on conflict")
+	}
+}
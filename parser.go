@@ -0,0 +1,122 @@
+package env
+
+import (
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// parserEntry holds a registered parser in typed and type-erased form.
+type parserEntry struct {
+	// typed is a func(r *Reader, key string, value string) (T, error)
+	typed any
+
+	// erased calls typed and boxes the result
+	erased func(r *Reader, key string, value string) (any, error)
+}
+
+// parsers is the registry used by Get, GetMap and Bind, keyed by type.
+var parsers = struct {
+	sync.RWMutex
+	entries map[reflect.Type]parserEntry
+}{
+	entries: map[reflect.Type]parserEntry{},
+}
+
+func init() {
+	registerBuiltin(parseListString)
+	registerBuiltin(parseBool)
+	registerBuiltin(parseInt)
+	registerBuiltin(parseInt8)
+	registerBuiltin(parseInt16)
+	registerBuiltin(parseInt32)
+	registerBuiltin(parseInt64)
+	registerBuiltin(parseUint)
+	registerBuiltin(parseUint8)
+	registerBuiltin(parseUint16)
+	registerBuiltin(parseUint32)
+	registerBuiltin(parseUint64)
+	registerBuiltin(parseFloat32)
+	registerBuiltin(parseFloat64)
+	registerReaderParser(func(r *Reader, key string, value string) (time.Duration, error) {
+		return parseDuration(key, value, r.durationUnit)
+	})
+}
+
+// RegisterParser registers the parser used by Get, GetMap and Bind for
+// values of type T, replacing any parser already registered for T.
+//
+// The parser receives the processed value (after base64:/obfuscated:
+// decoding). Its error is wrapped in an error naming the variable.
+//
+// Parameters:
+//
+//	parse: The function converting a string into a T.
+func RegisterParser[T any](parse func(value string) (T, error)) {
+	if parse == nil {
+		panic("env: RegisterParser called with a nil parser")
+	}
+
+	typeName := reflect.TypeFor[T]().String()
+
+	registerReaderParser(func(_ *Reader, key string, value string) (T, error) {
+		parsed, err := parse(value)
+		if err != nil {
+			var zero T
+			return zero, invalidError(key, value, typeName, err)
+		}
+		return parsed, nil
+	})
+}
+
+// registerBuiltin registers one of the package's own parsers.
+func registerBuiltin[T any](parse func(key string, value string) (T, error)) {
+	registerReaderParser(func(_ *Reader, key string, value string) (T, error) {
+		return parse(key, value)
+	})
+}
+
+// registerReaderParser registers a parser that may depend on reader settings.
+func registerReaderParser[T any](parse func(r *Reader, key string, value string) (T, error)) {
+	parsers.Lock()
+	defer parsers.Unlock()
+
+	parsers.entries[reflect.TypeFor[T]()] = parserEntry{
+		typed: parse,
+		erased: func(r *Reader, key string, value string) (any, error) {
+			return parse(r, key, value)
+		},
+	}
+}
+
+// lookupParser returns the parser registered for T.
+func lookupParser[T any]() (func(r *Reader, key string, value string) (T, error), bool) {
+	parsers.RLock()
+	defer parsers.RUnlock()
+
+	entry, ok := parsers.entries[reflect.TypeFor[T]()]
+	if !ok {
+		return nil, false
+	}
+
+	return entry.typed.(func(r *Reader, key string, value string) (T, error)), true
+}
+
+// lookupErasedParser returns the parser registered for typ.
+func lookupErasedParser(typ reflect.Type) (func(r *Reader, key string, value string) (any, error), bool) {
+	parsers.RLock()
+	defer parsers.RUnlock()
+
+	entry, ok := parsers.entries[typ]
+	return entry.erased, ok
+}
+
+// parseFloat32 parses a float32 value read from the environment variable key.
+func parseFloat32(key string, valueStr string) (float32, error) {
+	value, err := strconv.ParseFloat(valueStr, 32)
+	if err != nil {
+		return 0, invalidError(key, valueStr, "float32", nil)
+	}
+	return float32(value), nil
+}
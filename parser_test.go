@@ -0,0 +1,74 @@
+package env
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type testRegion string
+
+func parseTestRegion(value string) (testRegion, error) {
+	switch value {
+	case "eu", "us":
+		return testRegion(value), nil
+	}
+	return "", errors.New("unknown region")
+}
+
+func TestRegisterParser(t *testing.T) {
+	RegisterParser(parseTestRegion)
+
+	os.Setenv("TEST_PARSER_REGION", "eu")
+	os.Setenv("TEST_PARSER_INVALID", "mars")
+	os.Setenv("TEST_PARSER_MAP", "primary=eu,backup=us")
+	defer os.Unsetenv("TEST_PARSER_REGION")
+	defer os.Unsetenv("TEST_PARSER_INVALID")
+	defer os.Unsetenv("TEST_PARSER_MAP")
+
+	region, err := GetOrError[testRegion]("TEST_PARSER_REGION")
+	if err != nil || region != "eu" {
+		t.Errorf("Expected 'eu', got '%s' (%v)", region, err)
+	}
+
+	_, err = GetOrError[testRegion]("TEST_PARSER_INVALID")
+	if err == nil || !strings.Contains(err.Error(), "TEST_PARSER_INVALID") || !strings.Contains(err.Error(), "unknown region") {
+		t.Errorf("Expected error naming the key and cause, got '%v'", err)
+	}
+
+	regions := GetMap[testRegion]("TEST_PARSER_MAP")
+	if !reflect.DeepEqual(regions, map[string]testRegion{"primary": "eu", "backup": "us"}) {
+		t.Errorf("Expected map[backup:us primary:eu], got %v", regions)
+	}
+
+	type config struct {
+		Region testRegion `env:"TEST_PARSER_REGION"`
+	}
+	cfg := config{}
+	if err := Bind(&cfg); err != nil || cfg.Region != "eu" {
+		t.Errorf("Expected 'eu', got '%s' (%v)", cfg.Region, err)
+	}
+}
+
+func TestRegisterParserNil(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("The code did not panic")
+		}
+	}()
+	RegisterParser[testRegion](nil)
+}
+
+func TestBindNamedKinds(t *testing.T) {
+	type port int
+	type config struct {
+		Port port `env:"PORT"`
+	}
+
+	cfg := config{}
+	if err := New(MapSource{"PORT": "8080"}).Bind(&cfg); err != nil || cfg.Port != 8080 {
+		t.Errorf("Expected 8080, got %d (%v)", cfg.Port, err)
+	}
+}
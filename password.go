@@ -0,0 +1,144 @@
+package env
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"golang.org/x/term"
+)
+
+// PasswordSource provides a vault password. It returns an error if the
+// password is not available from that source.
+//
+// The sources that can only be read once (PasswordFromFD, PasswordFromEnv
+// and PasswordFromPrompt) remember their result, so one source can be
+// shared by several vaults, e.g. in LoadVaults, and is only asked once.
+type PasswordSource func() (string, error)
+
+// ResolvePassword returns the password of the first source that provides
+// a non-empty one, trying the sources in order.
+//
+// Parameters:
+//
+//	sources: The sources to try.
+//
+// Returns:
+//
+//	The password, or an error matching ErrNotFound that lists why each
+//	source failed.
+func ResolvePassword(sources ...PasswordSource) (string, error) {
+	if len(sources) == 0 {
+		return "", &sentinelError{message: "password is required", sentinel: ErrNotFound}
+	}
+
+	reasons := make([]string, 0, len(sources))
+
+	for _, source := range sources {
+		password, err := source()
+		if err == nil && password == "" {
+			err = errors.New("password is empty")
+		}
+		if err == nil {
+			return password, nil
+		}
+		reasons = append(reasons, err.Error())
+	}
+
+	return "", &sentinelError{
+		message:  "password is required, but no password source resolved: " + strings.Join(reasons, "; "),
+		sentinel: ErrNotFound,
+	}
+}
+
+// PasswordFromFile reads the password from a file, such as a mounted
+// secret. A single trailing newline is removed.
+func PasswordFromFile(path string) PasswordSource {
+	return func() (string, error) {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("password file: %w", err)
+		}
+		return trimPasswordLine(string(content)), nil
+	}
+}
+
+// PasswordFromFD reads the password from an inherited file descriptor,
+// e.g. 3 for a password passed as "3<password.txt". The descriptor is read
+// to the end and closed. A single trailing newline is removed.
+func PasswordFromFD(fd uintptr) PasswordSource {
+	return readOnce(func() (string, error) {
+		file := os.NewFile(fd, fmt.Sprintf("fd %d", fd))
+		if file == nil {
+			return "", fmt.Errorf("password descriptor %d is not valid", fd)
+		}
+		defer file.Close()
+
+		content, err := io.ReadAll(file)
+		if err != nil {
+			return "", fmt.Errorf("password descriptor %d: %w", fd, err)
+		}
+		return trimPasswordLine(string(content)), nil
+	})
+}
+
+// PasswordFromEnv reads the password from the environment variable name
+// and unsets the variable, so that it is not inherited by child processes
+// or read again.
+func PasswordFromEnv(name string) PasswordSource {
+	return readOnce(func() (string, error) {
+		password, ok := os.LookupEnv(name)
+		if !ok || password == "" {
+			return "", fmt.Errorf("environment variable '%s' is not set", name)
+		}
+		if err := os.Unsetenv(name); err != nil {
+			return "", err
+		}
+		return password, nil
+	})
+}
+
+// PasswordFromPrompt asks for the password on the terminal, writing prompt
+// to standard error and reading standard input without echo. It fails if
+// standard input is not a terminal.
+func PasswordFromPrompt(prompt string) PasswordSource {
+	return readOnce(func() (string, error) {
+		fd := int(os.Stdin.Fd())
+		if !term.IsTerminal(fd) {
+			return "", errors.New("cannot prompt for password: standard input is not a terminal")
+		}
+
+		fmt.Fprint(os.Stderr, prompt)
+		password, err := term.ReadPassword(fd)
+		fmt.Fprintln(os.Stderr)
+
+		if err != nil {
+			return "", fmt.Errorf("password prompt: %w", err)
+		}
+		return string(password), nil
+	})
+}
+
+// readOnce returns a source that calls source the first time it is asked
+// and returns the same result afterwards.
+func readOnce(source PasswordSource) PasswordSource {
+	var once sync.Once
+	var password string
+	var err error
+
+	return func() (string, error) {
+		once.Do(func() {
+			password, err = source()
+		})
+		return password, err
+	}
+}
+
+// trimPasswordLine removes a single trailing line ending.
+func trimPasswordLine(password string) string {
+	password = strings.TrimSuffix(password, "\n")
+	return strings.TrimSuffix(password, "\r")
+}
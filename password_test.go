@@ -0,0 +1,93 @@
+package env
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPasswordFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "password")
+	os.WriteFile(path, []byte("s3cr3t\n"), 0o600)
+
+	password, err := PasswordFromFile(path)()
+	if err != nil || password != "s3cr3t" {
+		t.Errorf("Expected ('s3cr3t', nil), got ('%s', %v)", password, err)
+	}
+
+	if _, err := PasswordFromFile(filepath.Join(t.TempDir(), "missing"))(); err == nil {
+		t.Error("Expected error for a missing file, got nil")
+	}
+}
+
+func TestPasswordFromEnv(t *testing.T) {
+	os.Setenv("TEST_PASSWORD_ENV", "s3cr3t")
+	defer os.Unsetenv("TEST_PASSWORD_ENV")
+
+	password, err := PasswordFromEnv("TEST_PASSWORD_ENV")()
+	if err != nil || password != "s3cr3t" {
+		t.Errorf("Expected ('s3cr3t', nil), got ('%s', %v)", password, err)
+	}
+
+	if _, ok := os.LookupEnv("TEST_PASSWORD_ENV"); ok {
+		t.Error("Expected the variable to be unset after use")
+	}
+
+	if _, err := PasswordFromEnv("TEST_PASSWORD_ENV")(); err == nil {
+		t.Error("Expected error for an unset variable, got nil")
+	}
+}
+
+func TestResolvePassword(t *testing.T) {
+	os.Setenv("TEST_PASSWORD_RESOLVE", "from-env")
+	defer os.Unsetenv("TEST_PASSWORD_RESOLVE")
+
+	password, err := ResolvePassword(
+		PasswordFromFile(filepath.Join(t.TempDir(), "missing")),
+		PasswordFromEnv("TEST_PASSWORD_RESOLVE"),
+		PasswordFromPrompt("Password: "),
+	)
+	if err != nil || password != "from-env" {
+		t.Errorf("Expected ('from-env', nil), got ('%s', %v)", password, err)
+	}
+
+	_, err = ResolvePassword(
+		PasswordFromEnv("TEST_PASSWORD_RESOLVE_MISSING"),
+		func() (string, error) { return "", nil },
+	)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+	if err == nil || !strings.Contains(err.Error(), "TEST_PASSWORD_RESOLVE_MISSING") || !strings.Contains(err.Error(), "password is empty") {
+		t.Errorf("Expected error to list every source, got %v", err)
+	}
+}
+
+func TestLoadVaultPasswordSources(t *testing.T) {
+	vault := createTestVault(t, "s3cr3t", map[string]string{"TEST_PASSWORD_SOURCE_KEY": "value"})
+	defer os.Unsetenv("TEST_PASSWORD_SOURCE_KEY")
+
+	path := filepath.Join(t.TempDir(), "password")
+	os.WriteFile(path, []byte("s3cr3t\n"), 0o600)
+
+	_, err := LoadVaultWithOptions(VaultOptions{
+		VaultFilePath:   vault,
+		PasswordSources: []PasswordSource{PasswordFromEnv("TEST_PASSWORD_SOURCE_UNSET"), PasswordFromFile(path)},
+	})
+	if err != nil {
+		t.Fatalf("Expected nil error, got '%s'", err)
+	}
+	if os.Getenv("TEST_PASSWORD_SOURCE_KEY") != "value" {
+		t.Errorf("Expected 'value', got '%s'", os.Getenv("TEST_PASSWORD_SOURCE_KEY"))
+	}
+
+	_, err = LoadVaultWithOptions(VaultOptions{
+		VaultFilePath:   vault,
+		PasswordSources: []PasswordSource{PasswordFromEnv("TEST_PASSWORD_SOURCE_UNSET")},
+	})
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound when no source resolves, got %v", err)
+	}
+}
@@ -0,0 +1,31 @@
+//go:build unix
+
+package env
+
+import (
+	"os"
+	"syscall"
+	"testing"
+)
+
+func TestPasswordFromFD(t *testing.T) {
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Close()
+
+	writer.WriteString("s3cr3t\r\n")
+	writer.Close()
+
+	// PasswordFromFD closes the descriptor it is given, so it gets its own
+	fd, err := syscall.Dup(int(reader.Fd()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	password, err := PasswordFromFD(uintptr(fd))()
+	if err != nil || password != "s3cr3t" {
+		t.Errorf("Expected ('s3cr3t', nil), got ('%s', %v)", password, err)
+	}
+}
@@ -8,6 +8,17 @@ import (
 )
 
 func envProcess(value string) string {
+	processed, err := decodeValue(value)
+	if err != nil {
+		return err.Error()
+	}
+
+	return processed
+}
+
+// decodeValue trims value and decodes a base64: or obfuscated: prefixed
+// value. It returns an error if the value cannot be decoded.
+func decodeValue(value string) (string, error) {
 	valueTrimmed := strings.TrimSpace(value)
 
 	if strings.HasPrefix(valueTrimmed, "base64:") {
@@ -16,10 +27,10 @@ func envProcess(value string) string {
 		valueDecoded, err := base64.URLEncoding.DecodeString(valueNoPrefix)
 
 		if err != nil {
-			return err.Error()
+			return "", err
 		}
 
-		return string(valueDecoded)
+		return string(valueDecoded), nil
 	}
 
 	if strings.HasPrefix(valueTrimmed, "obfuscated:") {
@@ -28,11 +39,11 @@ func envProcess(value string) string {
 		valueDecoded, err := envenc.Deobfuscate(valueNoPrefix)
 
 		if err != nil {
-			return err.Error()
+			return "", err
 		}
 
-		return string(valueDecoded)
+		return string(valueDecoded), nil
 	}
 
-	return valueTrimmed
+	return valueTrimmed, nil
 }
@@ -0,0 +1,279 @@
+package env
+
+import (
+	"errors"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Reader reads typed values from a Source.
+//
+// The package-level Get functions use a default Reader backed by the
+// process environment (see Default and SetDefault).
+type Reader struct {
+	source       Source
+	interpolate  bool
+	durationUnit time.Duration
+	emptyAsSet   bool
+	strict       bool
+	onMalformed  func(err error)
+
+	// secret redacts every value in errors, for readers over vaults
+	secret bool
+}
+
+// Option configures a Reader.
+type Option func(*Reader)
+
+// WithInterpolation enables expansion of ${VAR}, ${VAR:-default},
+// ${VAR:?message} and $$ in values read by the Reader. See Expand.
+func WithInterpolation() Option {
+	return func(r *Reader) {
+		r.interpolate = true
+	}
+}
+
+// WithDurationUnit sets the unit applied to bare numbers read by the
+// Duration getters, e.g. "30" with time.Second is 30 seconds.
+// The default unit is DefaultDurationUnit.
+func WithDurationUnit(unit time.Duration) Option {
+	return func(r *Reader) {
+		r.durationUnit = unit
+	}
+}
+
+// WithEmptyAsSet makes the Reader treat a variable that is set to an
+// empty value (FOO=) as set. By default it is treated as not set, so
+// GetStringOrDefault returns the default and GetStringOrError fails.
+//
+// With this option GetStringOrError returns "", LookupString returns
+// ("", true), and the typed getters report the empty value as invalid.
+func WithEmptyAsSet() Option {
+	return func(r *Reader) {
+		r.emptyAsSet = true
+	}
+}
+
+// WithStrict makes the Reader panic when a value is set but malformed,
+// instead of falling back to the default (or zero value) in the Get,
+// OrDefault and Lookup getters. Only errors matching ErrInvalid panic:
+// missing values and usage errors, such as an empty list separator, still
+// fall back. The OrError getters are unaffected.
+func WithStrict() Option {
+	return func(r *Reader) {
+		r.strict = true
+	}
+}
+
+// WithMalformedHook sets a function called with the error whenever a
+// malformed value is discarded in favour of the default (or zero value),
+// e.g. to log a warning. The error matches ErrInvalid; values of secret
+// variables are redacted in its message.
+func WithMalformedHook(hook func(err error)) Option {
+	return func(r *Reader) {
+		r.onMalformed = hook
+	}
+}
+
+// defaultReader backs the package-level functions.
+var defaultReader atomic.Pointer[Reader]
+
+func init() {
+	defaultReader.Store(New(OSSource()))
+}
+
+// New creates a Reader over the given source.
+//
+// If source is nil, the process environment is used.
+func New(source Source, options ...Option) *Reader {
+	if source == nil {
+		source = OSSource()
+	}
+
+	r := &Reader{
+		source:       source,
+		durationUnit: DefaultDurationUnit,
+	}
+
+	for _, option := range options {
+		option(r)
+	}
+
+	return r
+}
+
+// Default returns the Reader used by the package-level functions.
+func Default() *Reader {
+	return defaultReader.Load()
+}
+
+// SetDefault replaces the Reader used by the package-level functions.
+//
+// Passing nil restores a Reader over the process environment.
+func SetDefault(r *Reader) {
+	if r == nil {
+		r = New(OSSource())
+	}
+	defaultReader.Store(r)
+}
+
+// Strict returns a copy of the default Reader with strict mode enabled,
+// for a single strict read:
+//
+//	port := env.Strict().GetIntOrDefault("DB_PORT", 5432)
+//
+// See WithStrict.
+func Strict() *Reader {
+	return Default().Strict()
+}
+
+// Strict returns a copy of r with strict mode enabled. See WithStrict.
+func (r *Reader) Strict() *Reader {
+	strict := *r
+	strict.strict = true
+	return &strict
+}
+
+// raw returns the unprocessed value of key, or an empty string if the key
+// is not present.
+func (r *Reader) raw(key string) string {
+	value, _ := r.source.Lookup(key)
+	return value
+}
+
+// lookup returns the processed value of key and whether it is set.
+//
+// A key with an empty value is treated as not set unless the reader was
+// created WithEmptyAsSet. The error is non-nil only if interpolation fails.
+func (r *Reader) lookup(key string) (string, bool, error) {
+	value, ok, err := r.resolve(key, nil)
+	if err == nil && value == "" && !r.emptyAsSet {
+		ok = false
+	}
+	return value, ok, err
+}
+
+// readValue looks up key and parses its value. A missing key produces an
+// error matching ErrNotFound; parse errors are annotated for redaction.
+func readValue[T any](r *Reader, key string, parse func(key string, value string) (T, error)) (T, error) {
+	var zero T
+
+	valueStr, ok, err := r.lookup(key)
+	if err != nil {
+		return zero, err
+	}
+	if !ok {
+		return zero, notFoundError(key)
+	}
+
+	value, err := parse(key, valueStr)
+	if err != nil {
+		return zero, r.annotate(key, err)
+	}
+
+	return value, nil
+}
+
+// annotate marks a *VarError about key as secret when the key or its raw
+// value suggests a secret.
+func (r *Reader) annotate(key string, err error) error {
+	if r.isSecret(key) {
+		markSecret(err)
+	}
+	return err
+}
+
+// isSecret reports whether the value of key should be redacted in errors.
+// With interpolation, a value that references a secret is secret too.
+func (r *Reader) isSecret(key string) bool {
+	if r.secret || r.isSecretKey(key) {
+		return true
+	}
+
+	return r.interpolate && r.referencesSecret(r.raw(key), map[string]bool{key: true})
+}
+
+// isSecretKey reports whether the key name or its raw value suggests a
+// secret.
+func (r *Reader) isSecretKey(key string) bool {
+	if looksSecret(key) {
+		return true
+	}
+
+	raw := strings.TrimSpace(r.raw(key))
+
+	return strings.HasPrefix(raw, "base64:") || strings.HasPrefix(raw, "obfuscated:")
+}
+
+// referencesSecret reports whether value references, directly or through
+// other references or ${VAR:-default} operands, a key that is secret.
+// seen holds the keys already checked.
+func (r *Reader) referencesSecret(value string, seen map[string]bool) bool {
+	for i := 0; i+1 < len(value); i++ {
+		if value[i] != '$' {
+			continue
+		}
+
+		if value[i+1] == '$' {
+			i++
+			continue
+		}
+
+		if value[i+1] != '{' {
+			continue
+		}
+
+		end := matchingBrace(value, i+1)
+		if end < 0 {
+			return false
+		}
+
+		name, operand, _ := strings.Cut(value[i+2:end], ":")
+
+		if name != "" && !seen[name] {
+			seen[name] = true
+			if r.isSecretKey(name) || r.referencesSecret(r.raw(name), seen) {
+				return true
+			}
+		}
+
+		if operand != "" && r.referencesSecret(operand, seen) {
+			return true
+		}
+
+		i = end
+	}
+
+	return false
+}
+
+// orDefault returns value if err is nil, and defaultValue otherwise.
+// Malformed values are handled by accept.
+func orDefault[T any](r *Reader, value T, err error, defaultValue T) T {
+	if r.accept(err) {
+		return value
+	}
+	return defaultValue
+}
+
+// accept reports whether err is nil. An error matching ErrInvalid panics
+// in strict mode and is otherwise passed to the malformed hook before being
+// discarded. Other errors, such as a missing value or an unsupported type,
+// are discarded.
+func (r *Reader) accept(err error) bool {
+	if err == nil {
+		return true
+	}
+
+	if errors.Is(err, ErrInvalid) {
+		if r.strict {
+			panic(err)
+		}
+		if r.onMalformed != nil {
+			r.onMalformed(err)
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,160 @@
+package env
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestReader(t *testing.T) {
+	reader := New(MapSource{
+		"STRING":  "hello",
+		"ENCODED": "base64:aGVsbG8=",
+		"BOOL":    "yes",
+		"INT":     "123",
+		"FLOAT":   "123.45",
+		"INVALID": "abc",
+	})
+
+	if value := reader.GetString("STRING"); value != "hello" {
+		t.Errorf("Expected 'hello', got '%s'", value)
+	}
+	if value := reader.GetString("ENCODED"); value != "hello" {
+		t.Errorf("Expected 'hello', got '%s'", value)
+	}
+	if value := reader.GetStringOrDefault("NON_EXISTENT", "default"); value != "default" {
+		t.Errorf("Expected 'default', got '%s'", value)
+	}
+	if value := reader.GetBool("BOOL"); value != true {
+		t.Errorf("Expected true, got %v", value)
+	}
+	if value := reader.GetInt("INT"); value != 123 {
+		t.Errorf("Expected 123, got %d", value)
+	}
+	if value := reader.GetIntOrDefault("INVALID", 456); value != 456 {
+		t.Errorf("Expected 456, got %d", value)
+	}
+	if value := reader.GetFloat("FLOAT"); value != 123.45 {
+		t.Errorf("Expected 123.45, got %f", value)
+	}
+
+	if _, err := reader.GetStringOrError("NON_EXISTENT"); err == nil {
+		t.Error("Expected error, got nil")
+	}
+	if _, err := reader.GetIntOrError("INVALID"); err == nil {
+		t.Error("Expected error, got nil")
+	}
+}
+
+func TestReaderDoesNotReadProcessEnvironment(t *testing.T) {
+	os.Setenv("TEST_READER_ISOLATED", "process")
+	defer os.Unsetenv("TEST_READER_ISOLATED")
+
+	reader := New(MapSource{})
+	if value := reader.GetString("TEST_READER_ISOLATED"); value != "" {
+		t.Errorf("Expected '', got '%s'", value)
+	}
+}
+
+func TestNewWithNilSource(t *testing.T) {
+	os.Setenv("TEST_READER_NIL", "process")
+	defer os.Unsetenv("TEST_READER_NIL")
+
+	reader := New(nil)
+	if value := reader.GetString("TEST_READER_NIL"); value != "process" {
+		t.Errorf("Expected 'process', got '%s'", value)
+	}
+}
+
+func TestDefault(t *testing.T) {
+	os.Setenv("TEST_READER_DEFAULT", "process")
+	defer os.Unsetenv("TEST_READER_DEFAULT")
+
+	if value := Default().GetString("TEST_READER_DEFAULT"); value != "process" {
+		t.Errorf("Expected 'process', got '%s'", value)
+	}
+}
+
+func TestStrict(t *testing.T) {
+	reader := New(MapSource{"DB_PORT": "54e2"}, WithStrict())
+
+	if value := reader.GetIntOrDefault("MISSING", 5432); value != 5432 {
+		t.Errorf("Expected 5432 for a missing value, got %d", value)
+	}
+
+	defer func() {
+		r := recover()
+		if r == nil {
+			t.Fatal("Expected panic for a malformed value, got none")
+		}
+		if err, ok := r.(error); !ok || !errors.Is(err, ErrInvalid) {
+			t.Errorf("Expected panic with ErrInvalid, got %v", r)
+		}
+	}()
+
+	reader.GetIntOrDefault("DB_PORT", 5432)
+}
+
+func TestStrictUsageErrors(t *testing.T) {
+	var reported []error
+
+	reader := New(MapSource{"HOSTS": "a,b", "LABELS": "a=b"}, WithStrict(), WithMalformedHook(func(err error) {
+		reported = append(reported, err)
+	}))
+
+	if value := reader.GetStringSliceOrDefault("HOSTS", []string{"x"}, WithSeparator("")); len(value) != 1 || value[0] != "x" {
+		t.Errorf("Expected [x], got %v", value)
+	}
+	if value := reader.GetStringMapOrDefault("LABELS", nil, WithKeyValueSeparator("")); value != nil {
+		t.Errorf("Expected nil, got %v", value)
+	}
+
+	if len(reported) != 0 {
+		t.Errorf("Expected no reported errors, got %v", reported)
+	}
+}
+
+func TestStrictPerCall(t *testing.T) {
+	os.Setenv("TEST_READER_STRICT", "maybe")
+	defer os.Unsetenv("TEST_READER_STRICT")
+
+	if value := GetBoolOrDefault("TEST_READER_STRICT", true); value != true {
+		t.Errorf("Expected true, got %v", value)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Expected panic for a malformed value, got none")
+		}
+	}()
+
+	Strict().GetBoolOrDefault("TEST_READER_STRICT", true)
+}
+
+func TestMalformedHook(t *testing.T) {
+	var reported []error
+
+	reader := New(MapSource{"DB_PORT": "54e2", "DEBUG": "maybe"}, WithMalformedHook(func(err error) {
+		reported = append(reported, err)
+	}))
+
+	if value := reader.GetIntOrDefault("DB_PORT", 5432); value != 5432 {
+		t.Errorf("Expected 5432, got %d", value)
+	}
+	if value := reader.GetBool("DEBUG"); value != false {
+		t.Errorf("Expected false, got %v", value)
+	}
+	if _, ok := reader.LookupInt("DB_PORT"); ok {
+		t.Error("Expected false, got true")
+	}
+	reader.GetIntOrDefault("MISSING", 1)
+
+	if len(reported) != 3 {
+		t.Fatalf("Expected 3 reported errors, got %d: %v", len(reported), reported)
+	}
+
+	var varErr *VarError
+	if !errors.As(reported[0], &varErr) || varErr.Key != "DB_PORT" {
+		t.Errorf("Expected *VarError for DB_PORT, got %v", reported[0])
+	}
+}
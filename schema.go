@@ -0,0 +1,265 @@
+package env
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Var declares an environment variable in a Schema.
+type Var struct {
+	// Key is the variable name.
+	Key string
+
+	// Type is the type the value must parse as, e.g.
+	// reflect.TypeFor[int](). Any type supported by Bind may be used.
+	// Defaults to string when nil.
+	Type reflect.Type
+
+	// Required reports the variable as missing when it is not set and
+	// has no Default.
+	Required bool
+
+	// Default is the value used when the variable is not set. It is
+	// validated like a value read from the environment.
+	Default string
+
+	// Description documents the variable.
+	Description string
+
+	// Secret redacts the value in error messages.
+	Secret bool
+
+	// Min and Max, when not empty, are the inclusive bounds of a numeric
+	// value (including time.Duration), written like a value of Type,
+	// e.g. "1" or "30s".
+	Min string
+	Max string
+}
+
+// Schema is a list of variable declarations that can be validated as a
+// whole. It is safe for concurrent use.
+type Schema struct {
+	mu   sync.Mutex
+	vars []Var
+}
+
+// registry is the schema populated by Register.
+var registry = NewSchema()
+
+// Register declares variables in the package's registry, typically from
+// package-level var blocks or init functions next to the code that reads
+// them. Registering a key again replaces its declaration.
+//
+// The registry can be validated with ValidateRegistered and documented
+// with the generators of Registered (EnvExample, MarkdownTable and
+// JSONSchema).
+//
+// Register panics if a key is empty.
+func Register(vars ...Var) {
+	for _, v := range vars {
+		if v.Key == "" {
+			panic("env: Register called with an empty key")
+		}
+	}
+	registry.replace(vars...)
+}
+
+// Registered returns the schema holding the variables declared with
+// Register.
+func Registered() *Schema {
+	return registry
+}
+
+// ValidateRegistered checks the variables declared with Register against
+// the process environment. See (*Reader).Validate.
+func ValidateRegistered() error {
+	return registry.Validate()
+}
+
+// NewSchema creates a Schema declaring the given variables.
+func NewSchema(vars ...Var) *Schema {
+	return &Schema{vars: append([]Var{}, vars...)}
+}
+
+// Add declares more variables in the schema.
+func (s *Schema) Add(vars ...Var) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.vars = append(s.vars, vars...)
+}
+
+// Vars returns the declared variables, in declaration order.
+func (s *Schema) Vars() []Var {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Var{}, s.vars...)
+}
+
+// replace declares vars, replacing existing declarations of the same keys
+// in place.
+func (s *Schema) replace(vars ...Var) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, v := range vars {
+		index := slices.IndexFunc(s.vars, func(existing Var) bool {
+			return existing.Key == v.Key
+		})
+		if index >= 0 {
+			s.vars[index] = v
+			continue
+		}
+		s.vars = append(s.vars, v)
+	}
+}
+
+// Validate checks every declared variable against the process environment
+// using the default Reader. See (*Reader).Validate.
+func (s *Schema) Validate() error {
+	return Default().Validate(s)
+}
+
+// ValidationError lists every problem found by Validate.
+type ValidationError struct {
+	// Errors holds one error per invalid variable, in declaration order.
+	// Errors about values are *VarError and match ErrNotFound, ErrInvalid
+	// or ErrOutOfRange.
+	Errors []error
+}
+
+// Error returns the error message, one problem per line.
+func (e *ValidationError) Error() string {
+	lines := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		lines[i] = err.Error()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Unwrap returns the individual errors, so errors.Is and errors.As match
+// any of them.
+func (e *ValidationError) Unwrap() []error {
+	return e.Errors
+}
+
+// Validate checks every variable declared in schema against the reader's
+// source, reporting all problems at once instead of stopping at the first.
+//
+// A variable is invalid when it is required but not set, when its value
+// (or its default) cannot be parsed as its Type, or when it is outside
+// Min and Max.
+//
+// Parameters:
+//
+//	schema: The variables to check.
+//
+// Returns:
+//
+//	A *ValidationError listing every invalid variable, or nil.
+func (r *Reader) Validate(schema *Schema) error {
+	var errs []error
+
+	for _, v := range schema.Vars() {
+		if err := r.validateVar(v); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return &ValidationError{Errors: errs}
+}
+
+// validateVar checks a single declared variable.
+func (r *Reader) validateVar(v Var) error {
+	typ := v.Type
+	if typ == nil {
+		typ = reflect.TypeFor[string]()
+	}
+
+	valueStr, ok, err := r.lookup(v.Key)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		switch {
+		case v.Default != "":
+			valueStr = envProcess(v.Default)
+		case v.Required:
+			return notFoundError(v.Key)
+		default:
+			return nil
+		}
+	}
+
+	value, err := r.parseAs(v.Key, valueStr, typ)
+	if err == nil {
+		err = r.checkBounds(v, typ, valueStr, value)
+	}
+
+	if v.Secret {
+		markSecret(err)
+	}
+
+	return err
+}
+
+// checkBounds reports an error when value is outside the Min and Max of v.
+func (r *Reader) checkBounds(v Var, typ reflect.Type, valueStr string, value reflect.Value) error {
+	bounds := []struct {
+		name  string
+		bound string
+		fails func(c int) bool
+	}{
+		{"at least", v.Min, func(c int) bool { return c < 0 }},
+		{"at most", v.Max, func(c int) bool { return c > 0 }},
+	}
+
+	for _, b := range bounds {
+		if b.bound == "" {
+			continue
+		}
+
+		limit, err := r.parseAs(v.Key, b.bound, typ)
+		if err != nil {
+			return fmt.Errorf("schema for '%s': invalid bound '%s': %w", v.Key, b.bound, err)
+		}
+
+		c, ok := compareValues(value, limit)
+		if !ok {
+			return fmt.Errorf("schema for '%s': bounds are not supported for type %s", v.Key, typ)
+		}
+
+		if b.fails(c) {
+			return &VarError{
+				Key:   v.Key,
+				Value: valueStr,
+				Type:  typ.String(),
+				Err:   &sentinelError{message: "must be " + b.name + " " + b.bound, sentinel: ErrOutOfRange},
+			}
+		}
+	}
+
+	return nil
+}
+
+// compareValues compares two numeric values of the same type. It returns
+// false if the type is not numeric.
+func compareValues(a reflect.Value, b reflect.Value) (int, bool) {
+	switch a.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp.Compare(a.Int(), b.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return cmp.Compare(a.Uint(), b.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return cmp.Compare(a.Float(), b.Float()), true
+	}
+	return 0, false
+}
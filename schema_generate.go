@@ -0,0 +1,271 @@
+package env
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// SecretPlaceholder replaces the values of secret variables in generated
+// documents.
+const SecretPlaceholder = "<secret>"
+
+// jsonSchemaDialect is the JSON Schema version of JSONSchema documents.
+const jsonSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// EnvExample returns a commented .env.example document declaring every
+// variable of the schema, in declaration order.
+//
+// Each variable is preceded by its description and a line listing its
+// type, whether it is required and its bounds. Its value is the default,
+// or SecretPlaceholder for secret variables.
+func (s *Schema) EnvExample() string {
+	var b strings.Builder
+
+	for i, v := range s.Vars() {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+
+		for _, line := range strings.Split(strings.TrimSpace(v.Description), "\n") {
+			if line != "" {
+				b.WriteString("# " + strings.TrimSpace(line) + "\n")
+			}
+		}
+
+		b.WriteString("# " + strings.Join(varAttributes(v), ", ") + "\n")
+		b.WriteString(v.Key + "=" + quoteExampleValue(exampleValue(v)) + "\n")
+	}
+
+	return b.String()
+}
+
+// MarkdownTable returns a Markdown table documenting every variable of the
+// schema, in declaration order. Defaults of secret variables are shown as
+// SecretPlaceholder.
+func (s *Schema) MarkdownTable() string {
+	var b strings.Builder
+
+	b.WriteString("| Variable | Type | Required | Default | Description |\n")
+	b.WriteString("| --- | --- | --- | --- | --- |\n")
+
+	for _, v := range s.Vars() {
+		required := "no"
+		if v.Required {
+			required = "yes"
+		}
+
+		defaultValue := ""
+		if value := exampleValue(v); value != "" {
+			defaultValue = "`" + value + "`"
+		}
+
+		description := strings.Join(strings.Fields(v.Description), " ")
+		if bounds := varBounds(v); bounds != "" {
+			description = appendSentence(description, bounds+".")
+		}
+		if v.Secret {
+			description = appendSentence(description, "Secret.")
+		}
+
+		cells := []string{"`" + v.Key + "`", typeName(v.Type), required, defaultValue, description}
+		for i, cell := range cells {
+			cells[i] = strings.ReplaceAll(cell, "|", `\|`)
+		}
+
+		b.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+	}
+
+	return b.String()
+}
+
+// JSONSchema returns a JSON Schema (draft 2020-12) document describing the
+// variables of the schema as the properties of an object.
+//
+// Booleans, integers and floats map to the matching JSON types, with Min
+// and Max as minimum and maximum; other types are strings. Secret
+// variables are marked writeOnly and their defaults are omitted.
+func (s *Schema) JSONSchema() ([]byte, error) {
+	properties := map[string]map[string]any{}
+	required := []string{}
+
+	for _, v := range s.Vars() {
+		property, err := jsonSchemaProperty(v)
+		if err != nil {
+			return nil, err
+		}
+
+		properties[v.Key] = property
+
+		if v.Required && v.Default == "" {
+			required = append(required, v.Key)
+		}
+	}
+
+	document := map[string]any{
+		"$schema":    jsonSchemaDialect,
+		"type":       "object",
+		"properties": properties,
+	}
+
+	if len(required) > 0 {
+		document["required"] = required
+	}
+
+	return json.MarshalIndent(document, "", "  ")
+}
+
+// jsonSchemaProperty returns the JSON Schema of a single variable.
+func jsonSchemaProperty(v Var) (map[string]any, error) {
+	property := map[string]any{"type": jsonType(v.Type)}
+
+	if v.Description != "" {
+		property["description"] = v.Description
+	}
+
+	if v.Secret {
+		property["writeOnly"] = true
+	}
+
+	if v.Default != "" && !v.Secret {
+		value, err := jsonValue(v, v.Default)
+		if err != nil {
+			return nil, fmt.Errorf("schema for '%s': invalid default '%s': %w", v.Key, v.Default, err)
+		}
+		property["default"] = value
+	}
+
+	for name, bound := range map[string]string{"minimum": v.Min, "maximum": v.Max} {
+		if bound == "" {
+			continue
+		}
+
+		if property["type"] != "integer" && property["type"] != "number" {
+			continue
+		}
+
+		value, err := jsonValue(v, bound)
+		if err != nil {
+			return nil, fmt.Errorf("schema for '%s': invalid bound '%s': %w", v.Key, bound, err)
+		}
+		property[name] = value
+	}
+
+	return property, nil
+}
+
+// jsonType returns the JSON type used for values of typ.
+func jsonType(typ reflect.Type) string {
+	if typ == nil || typ == reflect.TypeFor[time.Duration]() {
+		return "string"
+	}
+
+	switch typ.Kind() {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	}
+
+	return "string"
+}
+
+// jsonValue converts value, written like a value of the variable, to the
+// JSON value matching jsonType.
+func jsonValue(v Var, value string) (any, error) {
+	if jsonType(v.Type) == "string" {
+		return envProcess(value), nil
+	}
+
+	parsed, err := New(MapSource{}).parseAs(v.Key, envProcess(value), v.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	return parsed.Interface(), nil
+}
+
+// exampleValue returns the value shown for v in generated documents.
+func exampleValue(v Var) string {
+	if v.Secret {
+		return SecretPlaceholder
+	}
+	return v.Default
+}
+
+// quoteExampleValue quotes value for a .env file when it contains
+// characters that would otherwise be misread. Values containing $ are
+// single-quoted when possible, since unquoted and double-quoted values are
+// expanded.
+func quoteExampleValue(value string) string {
+	if !strings.ContainsAny(value, " \t\n#\"'\\$") {
+		return value
+	}
+
+	if strings.Contains(value, "$") && !strings.ContainsAny(value, "'\n\r") {
+		return "'" + value + "'"
+	}
+
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, `$`, `\$`)
+
+	return `"` + replacer.Replace(value) + `"`
+}
+
+// varAttributes lists the type, requirement, bounds and secrecy of v.
+func varAttributes(v Var) []string {
+	attributes := []string{typeName(v.Type)}
+
+	if v.Required {
+		attributes = append(attributes, "required")
+	} else {
+		attributes = append(attributes, "optional")
+	}
+
+	if bounds := varBounds(v); bounds != "" {
+		attributes = append(attributes, strings.ToLower(bounds[:1])+bounds[1:])
+	}
+
+	if v.Secret {
+		attributes = append(attributes, "secret")
+	}
+
+	return attributes
+}
+
+// varBounds describes the Min and Max of v, or returns an empty string.
+func varBounds(v Var) string {
+	switch {
+	case v.Min != "" && v.Max != "":
+		return fmt.Sprintf("Between %s and %s", v.Min, v.Max)
+	case v.Min != "":
+		return "At least " + v.Min
+	case v.Max != "":
+		return "At most " + v.Max
+	}
+	return ""
+}
+
+// appendSentence appends sentence to text, ending text with a period
+// first if needed.
+func appendSentence(text string, sentence string) string {
+	if text == "" {
+		return sentence
+	}
+	if !strings.HasSuffix(text, ".") {
+		text += "."
+	}
+	return text + " " + sentence
+}
+
+// typeName returns the name of typ used in generated documents.
+func typeName(typ reflect.Type) string {
+	if typ == nil {
+		return "string"
+	}
+	return typ.String()
+}
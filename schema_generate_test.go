@@ -0,0 +1,166 @@
+package env
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/joho/godotenv"
+)
+
+func testGenerateSchema() *Schema {
+	return NewSchema(
+		Var{Key: "APP_NAME", Required: true, Description: "Application name"},
+		Var{Key: "DB_PORT", Type: reflect.TypeFor[int](), Default: "5432", Min: "1", Max: "65535", Description: "Database port"},
+		Var{Key: "DB_PASSWORD", Required: true, Secret: true, Default: "hunter2", Description: "Database password"},
+		Var{Key: "GREETING", Default: "hello world | everyone", Description: "Greeting"},
+		Var{Key: "TIMEOUT", Type: reflect.TypeFor[time.Duration](), Default: "30s"},
+	)
+}
+
+func TestEnvExample(t *testing.T) {
+	example := testGenerateSchema().EnvExample()
+
+	expected := []string{
+		"# Application name\n# string, required\nAPP_NAME=\n",
+		"# Database port\n# int, optional, between 1 and 65535\nDB_PORT=5432\n",
+		"# Database password\n# string, required, secret\nDB_PASSWORD=<secret>\n",
+		"# time.Duration, optional\nTIMEOUT=30s\n",
+	}
+	for _, part := range expected {
+		if !strings.Contains(example, part) {
+			t.Errorf("Expected example to contain %q, got:\n%s", part, example)
+		}
+	}
+
+	if strings.Contains(example, "hunter2") {
+		t.Errorf("Expected secret default to be hidden, got:\n%s", example)
+	}
+
+	values, err := godotenv.Unmarshal(example)
+	if err != nil {
+		t.Fatalf("Expected example to parse, got '%s'", err)
+	}
+	if values["GREETING"] != "hello world | everyone" {
+		t.Errorf("Expected 'hello world | everyone', got '%s'", values["GREETING"])
+	}
+}
+
+func TestEnvExampleDollar(t *testing.T) {
+	defaults := map[string]string{
+		"SPACED":    "x y${HOME}",
+		"BARE":      "$HOME",
+		"QUOTED":    "it's ${HOME}",
+		"MULTILINE": "a\n${HOME}",
+	}
+
+	var vars []Var
+	for _, key := range sortedKeys(defaults) {
+		vars = append(vars, Var{Key: key, Default: defaults[key]})
+	}
+
+	example := NewSchema(vars...).EnvExample()
+
+	values, err := godotenv.Unmarshal(example)
+	if err != nil {
+		t.Fatalf("Expected example to parse, got '%s'", err)
+	}
+	for key, expected := range defaults {
+		if values[key] != expected {
+			t.Errorf("%s: expected %q, got %q in:\n%s", key, expected, values[key], example)
+		}
+	}
+}
+
+func TestMarkdownTable(t *testing.T) {
+	table := testGenerateSchema().MarkdownTable()
+
+	lines := strings.Split(strings.TrimSpace(table), "\n")
+	if len(lines) != 7 {
+		t.Fatalf("Expected 7 lines, got %d:\n%s", len(lines), table)
+	}
+
+	expected := []string{
+		"| `APP_NAME` | string | yes |  | Application name |",
+		"| `DB_PORT` | int | no | `5432` | Database port. Between 1 and 65535. |",
+		"| `DB_PASSWORD` | string | yes | `<secret>` | Database password. Secret. |",
+		"| `GREETING` | string | no | `hello world \\| everyone` | Greeting |",
+	}
+	for _, line := range expected {
+		if !strings.Contains(table, line) {
+			t.Errorf("Expected table to contain %q, got:\n%s", line, table)
+		}
+	}
+}
+
+func TestJSONSchema(t *testing.T) {
+	data, err := testGenerateSchema().JSONSchema()
+	if err != nil {
+		t.Fatalf("Expected nil error, got '%s'", err)
+	}
+
+	var document struct {
+		Schema     string                    `json:"$schema"`
+		Type       string                    `json:"type"`
+		Properties map[string]map[string]any `json:"properties"`
+		Required   []string                  `json:"required"`
+	}
+	if err := json.Unmarshal(data, &document); err != nil {
+		t.Fatalf("Expected valid JSON, got '%s'", err)
+	}
+
+	if document.Type != "object" || document.Schema != jsonSchemaDialect {
+		t.Errorf("Expected object schema, got %s %s", document.Type, document.Schema)
+	}
+	if !reflect.DeepEqual(document.Required, []string{"APP_NAME"}) {
+		t.Errorf("Expected [APP_NAME], got %v", document.Required)
+	}
+
+	port := document.Properties["DB_PORT"]
+	if port["type"] != "integer" || port["default"] != 5432.0 || port["minimum"] != 1.0 || port["maximum"] != 65535.0 {
+		t.Errorf("Unexpected DB_PORT schema: %v", port)
+	}
+
+	password := document.Properties["DB_PASSWORD"]
+	if password["writeOnly"] != true || password["default"] != nil {
+		t.Errorf("Unexpected DB_PASSWORD schema: %v", password)
+	}
+
+	timeout := document.Properties["TIMEOUT"]
+	if timeout["type"] != "string" || timeout["default"] != "30s" {
+		t.Errorf("Unexpected TIMEOUT schema: %v", timeout)
+	}
+}
+
+func TestJSONSchemaInvalidDefault(t *testing.T) {
+	_, err := NewSchema(Var{Key: "PORT", Type: reflect.TypeFor[int](), Default: "http"}).JSONSchema()
+	if err == nil {
+		t.Error("Expected error for an invalid default, got nil")
+	}
+}
+
+func TestRegister(t *testing.T) {
+	defer func() { registry = NewSchema() }()
+
+	Register(Var{Key: "TEST_REGISTER_A", Required: true})
+	Register(Var{Key: "TEST_REGISTER_B"}, Var{Key: "TEST_REGISTER_A", Description: "replaced"})
+
+	vars := Registered().Vars()
+	if len(vars) != 2 || vars[0].Key != "TEST_REGISTER_A" || vars[0].Description != "replaced" {
+		t.Errorf("Expected A (replaced) and B, got %v", vars)
+	}
+
+	if err := ValidateRegistered(); err != nil {
+		t.Errorf("Expected nil error, got '%s'", err)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Expected panic for an empty key, got none")
+		}
+	}()
+
+	Register(Var{})
+}
@@ -0,0 +1,95 @@
+package env
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidate(t *testing.T) {
+	schema := NewSchema(
+		Var{Key: "APP_NAME", Required: true, Description: "Application name"},
+		Var{Key: "DB_PORT", Type: reflect.TypeFor[int](), Required: true, Min: "1", Max: "65535"},
+		Var{Key: "DB_PASSWORD", Type: reflect.TypeFor[int](), Secret: true},
+		Var{Key: "DEBUG", Type: reflect.TypeFor[bool](), Default: "false"},
+		Var{Key: "TIMEOUT", Type: reflect.TypeFor[time.Duration](), Max: "1m"},
+		Var{Key: "OPTIONAL", Type: reflect.TypeFor[int]()},
+	)
+
+	reader := New(MapSource{
+		"DB_PORT":     "70000",
+		"DB_PASSWORD": "hunter2",
+		"TIMEOUT":     "5m",
+	})
+
+	err := reader.Validate(schema)
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Expected *ValidationError, got %v", err)
+	}
+
+	if len(validationErr.Errors) != 4 {
+		t.Fatalf("Expected 4 errors, got %d: %v", len(validationErr.Errors), err)
+	}
+
+	if !errors.Is(validationErr.Errors[0], ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for APP_NAME, got %v", validationErr.Errors[0])
+	}
+	if !errors.Is(validationErr.Errors[1], ErrOutOfRange) {
+		t.Errorf("Expected ErrOutOfRange for DB_PORT, got %v", validationErr.Errors[1])
+	}
+	if !strings.Contains(validationErr.Errors[1].Error(), "must be at most 65535") {
+		t.Errorf("Expected bound in DB_PORT error, got '%s'", validationErr.Errors[1])
+	}
+	if !errors.Is(validationErr.Errors[2], ErrInvalid) || strings.Contains(validationErr.Errors[2].Error(), "hunter2") {
+		t.Errorf("Expected redacted ErrInvalid for DB_PASSWORD, got '%s'", validationErr.Errors[2])
+	}
+	if !errors.Is(validationErr.Errors[3], ErrOutOfRange) {
+		t.Errorf("Expected ErrOutOfRange for TIMEOUT, got %v", validationErr.Errors[3])
+	}
+
+	if !errors.Is(err, ErrNotFound) || !errors.Is(err, ErrInvalid) {
+		t.Errorf("Expected aggregated error to match ErrNotFound and ErrInvalid, got %v", err)
+	}
+}
+
+func TestValidateValid(t *testing.T) {
+	schema := NewSchema(
+		Var{Key: "DB_PORT", Type: reflect.TypeFor[uint16](), Required: true, Min: "1024"},
+		Var{Key: "RATIO", Type: reflect.TypeFor[float64](), Default: "0.5", Min: "0", Max: "1"},
+	)
+
+	if err := New(MapSource{"DB_PORT": "5432"}).Validate(schema); err != nil {
+		t.Errorf("Expected nil error, got '%s'", err)
+	}
+}
+
+func TestValidateMalformedDefault(t *testing.T) {
+	schema := NewSchema(Var{Key: "WORKERS", Type: reflect.TypeFor[int](), Default: "four"})
+
+	if err := New(MapSource{}).Validate(schema); !errors.Is(err, ErrInvalid) {
+		t.Errorf("Expected ErrInvalid for a malformed default, got %v", err)
+	}
+}
+
+func TestValidateUnsupportedBounds(t *testing.T) {
+	schema := NewSchema(Var{Key: "NAME", Min: "a"})
+
+	err := New(MapSource{"NAME": "b"}).Validate(schema)
+	if err == nil || !strings.Contains(err.Error(), "bounds are not supported") {
+		t.Errorf("Expected unsupported bounds error, got %v", err)
+	}
+}
+
+func TestSchemaAdd(t *testing.T) {
+	schema := NewSchema(Var{Key: "A"})
+	schema.Add(Var{Key: "B"}, Var{Key: "C"})
+
+	vars := schema.Vars()
+	if len(vars) != 3 || vars[2].Key != "C" {
+		t.Errorf("Expected 3 vars ending with C, got %v", vars)
+	}
+}
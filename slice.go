@@ -0,0 +1,242 @@
+package env
+
+import (
+	"fmt"
+)
+
+// GetStringSlice retrieves the string list value of an environment variable.
+// It returns nil if the key is not found or an element is not a valid string.
+func GetStringSlice(key string, options ...ListOption) []string {
+	return Default().GetStringSlice(key, options...)
+}
+
+// GetStringSliceOrDefault retrieves the string list value of an environment variable with a default.
+func GetStringSliceOrDefault(key string, defaultValue []string, options ...ListOption) []string {
+	return Default().GetStringSliceOrDefault(key, defaultValue, options...)
+}
+
+// GetStringSliceOrError retrieves the string list value of an environment variable,
+// returning an error if the key is not found or an element is not a valid string.
+func GetStringSliceOrError(key string, options ...ListOption) ([]string, error) {
+	return Default().GetStringSliceOrError(key, options...)
+}
+
+// GetStringSliceOrPanic retrieves the string list value of an environment variable,
+// panicking if not set or on parsing error.
+func GetStringSliceOrPanic(key string, options ...ListOption) []string {
+	return Default().GetStringSliceOrPanic(key, options...)
+}
+
+// GetIntSlice retrieves the int list value of an environment variable.
+// It returns nil if the key is not found or an element is not a valid integer.
+func GetIntSlice(key string, options ...ListOption) []int {
+	return Default().GetIntSlice(key, options...)
+}
+
+// GetIntSliceOrDefault retrieves the int list value of an environment variable with a default.
+func GetIntSliceOrDefault(key string, defaultValue []int, options ...ListOption) []int {
+	return Default().GetIntSliceOrDefault(key, defaultValue, options...)
+}
+
+// GetIntSliceOrError retrieves the int list value of an environment variable,
+// returning an error if the key is not found or an element is not a valid integer.
+func GetIntSliceOrError(key string, options ...ListOption) ([]int, error) {
+	return Default().GetIntSliceOrError(key, options...)
+}
+
+// GetIntSliceOrPanic retrieves the int list value of an environment variable,
+// panicking if not set or on parsing error.
+func GetIntSliceOrPanic(key string, options ...ListOption) []int {
+	return Default().GetIntSliceOrPanic(key, options...)
+}
+
+// GetFloatSlice retrieves the float64 list value of an environment variable.
+// It returns nil if the key is not found or an element is not a valid float.
+func GetFloatSlice(key string, options ...ListOption) []float64 {
+	return Default().GetFloatSlice(key, options...)
+}
+
+// GetFloatSliceOrDefault retrieves the float64 list value of an environment variable with a default.
+func GetFloatSliceOrDefault(key string, defaultValue []float64, options ...ListOption) []float64 {
+	return Default().GetFloatSliceOrDefault(key, defaultValue, options...)
+}
+
+// GetFloatSliceOrError retrieves the float64 list value of an environment variable,
+// returning an error if the key is not found or an element is not a valid float.
+func GetFloatSliceOrError(key string, options ...ListOption) ([]float64, error) {
+	return Default().GetFloatSliceOrError(key, options...)
+}
+
+// GetFloatSliceOrPanic retrieves the float64 list value of an environment variable,
+// panicking if not set or on parsing error.
+func GetFloatSliceOrPanic(key string, options ...ListOption) []float64 {
+	return Default().GetFloatSliceOrPanic(key, options...)
+}
+
+// GetBoolSlice retrieves the bool list value of an environment variable.
+// It returns nil if the key is not found or an element is not a valid boolean.
+func GetBoolSlice(key string, options ...ListOption) []bool {
+	return Default().GetBoolSlice(key, options...)
+}
+
+// GetBoolSliceOrDefault retrieves the bool list value of an environment variable with a default.
+func GetBoolSliceOrDefault(key string, defaultValue []bool, options ...ListOption) []bool {
+	return Default().GetBoolSliceOrDefault(key, defaultValue, options...)
+}
+
+// GetBoolSliceOrError retrieves the bool list value of an environment variable,
+// returning an error if the key is not found or an element is not a valid boolean.
+func GetBoolSliceOrError(key string, options ...ListOption) ([]bool, error) {
+	return Default().GetBoolSliceOrError(key, options...)
+}
+
+// GetBoolSliceOrPanic retrieves the bool list value of an environment variable,
+// panicking if not set or on parsing error.
+func GetBoolSliceOrPanic(key string, options ...ListOption) []bool {
+	return Default().GetBoolSliceOrPanic(key, options...)
+}
+
+// GetStringSlice retrieves the string list value of key.
+// It returns nil if the key is not found or an element is not a valid string.
+func (r *Reader) GetStringSlice(key string, options ...ListOption) []string {
+	value, err := r.GetStringSliceOrError(key, options...)
+	return orDefault(r, value, err, nil)
+}
+
+// GetStringSliceOrDefault retrieves the string list value of key with a default.
+func (r *Reader) GetStringSliceOrDefault(key string, defaultValue []string, options ...ListOption) []string {
+	value, err := r.GetStringSliceOrError(key, options...)
+	return orDefault(r, value, err, defaultValue)
+}
+
+// GetStringSliceOrError retrieves the string list value of key,
+// returning an error if the key is not found or an element is not a valid string.
+func (r *Reader) GetStringSliceOrError(key string, options ...ListOption) ([]string, error) {
+	return readSlice(r, key, parseListString, options)
+}
+
+// GetStringSliceOrPanic retrieves the string list value of key,
+// panicking if not set or on parsing error.
+func (r *Reader) GetStringSliceOrPanic(key string, options ...ListOption) []string {
+	value, err := r.GetStringSliceOrError(key, options...)
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
+// GetIntSlice retrieves the int list value of key.
+// It returns nil if the key is not found or an element is not a valid integer.
+func (r *Reader) GetIntSlice(key string, options ...ListOption) []int {
+	value, err := r.GetIntSliceOrError(key, options...)
+	return orDefault(r, value, err, nil)
+}
+
+// GetIntSliceOrDefault retrieves the int list value of key with a default.
+func (r *Reader) GetIntSliceOrDefault(key string, defaultValue []int, options ...ListOption) []int {
+	value, err := r.GetIntSliceOrError(key, options...)
+	return orDefault(r, value, err, defaultValue)
+}
+
+// GetIntSliceOrError retrieves the int list value of key,
+// returning an error if the key is not found or an element is not a valid integer.
+func (r *Reader) GetIntSliceOrError(key string, options ...ListOption) ([]int, error) {
+	return readSlice(r, key, parseInt, options)
+}
+
+// GetIntSliceOrPanic retrieves the int list value of key,
+// panicking if not set or on parsing error.
+func (r *Reader) GetIntSliceOrPanic(key string, options ...ListOption) []int {
+	value, err := r.GetIntSliceOrError(key, options...)
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
+// GetFloatSlice retrieves the float64 list value of key.
+// It returns nil if the key is not found or an element is not a valid float.
+func (r *Reader) GetFloatSlice(key string, options ...ListOption) []float64 {
+	value, err := r.GetFloatSliceOrError(key, options...)
+	return orDefault(r, value, err, nil)
+}
+
+// GetFloatSliceOrDefault retrieves the float64 list value of key with a default.
+func (r *Reader) GetFloatSliceOrDefault(key string, defaultValue []float64, options ...ListOption) []float64 {
+	value, err := r.GetFloatSliceOrError(key, options...)
+	return orDefault(r, value, err, defaultValue)
+}
+
+// GetFloatSliceOrError retrieves the float64 list value of key,
+// returning an error if the key is not found or an element is not a valid float.
+func (r *Reader) GetFloatSliceOrError(key string, options ...ListOption) ([]float64, error) {
+	return readSlice(r, key, parseFloat64, options)
+}
+
+// GetFloatSliceOrPanic retrieves the float64 list value of key,
+// panicking if not set or on parsing error.
+func (r *Reader) GetFloatSliceOrPanic(key string, options ...ListOption) []float64 {
+	value, err := r.GetFloatSliceOrError(key, options...)
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
+// GetBoolSlice retrieves the bool list value of key.
+// It returns nil if the key is not found or an element is not a valid boolean.
+func (r *Reader) GetBoolSlice(key string, options ...ListOption) []bool {
+	value, err := r.GetBoolSliceOrError(key, options...)
+	return orDefault(r, value, err, nil)
+}
+
+// GetBoolSliceOrDefault retrieves the bool list value of key with a default.
+func (r *Reader) GetBoolSliceOrDefault(key string, defaultValue []bool, options ...ListOption) []bool {
+	value, err := r.GetBoolSliceOrError(key, options...)
+	return orDefault(r, value, err, defaultValue)
+}
+
+// GetBoolSliceOrError retrieves the bool list value of key,
+// returning an error if the key is not found or an element is not a valid boolean.
+func (r *Reader) GetBoolSliceOrError(key string, options ...ListOption) ([]bool, error) {
+	return readSlice(r, key, parseBool, options)
+}
+
+// GetBoolSliceOrPanic retrieves the bool list value of key,
+// panicking if not set or on parsing error.
+func (r *Reader) GetBoolSliceOrPanic(key string, options ...ListOption) []bool {
+	value, err := r.GetBoolSliceOrError(key, options...)
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
+// readSlice splits the value of key into elements and parses each one.
+// Element errors name the element by its position in the value, counting
+// skipped empty elements, e.g. 'PORTS[2]' for "x" in "1,,x".
+func readSlice[T any](r *Reader, key string, parse func(key string, value string) (T, error), options []ListOption) ([]T, error) {
+	return readValue(r, key, func(key string, valueStr string) ([]T, error) {
+		elements, indexes, err := splitList(key, valueStr, newListConfig(options))
+		if err != nil {
+			return nil, err
+		}
+
+		values := make([]T, len(elements))
+
+		for i, element := range elements {
+			value, err := parse(fmt.Sprintf("%s[%d]", key, indexes[i]), element)
+			if err != nil {
+				return nil, err
+			}
+			values[i] = value
+		}
+
+		return values, nil
+	})
+}
+
+// parseListString returns the element unchanged.
+func parseListString(_ string, value string) (string, error) {
+	return value, nil
+}
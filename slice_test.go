@@ -0,0 +1,98 @@
+package env
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGetStringSlice(t *testing.T) {
+	os.Setenv("TEST_SLICE", "https://a.example, https://b.example")
+	value := GetStringSlice("TEST_SLICE")
+	if !reflect.DeepEqual(value, []string{"https://a.example", "https://b.example"}) {
+		t.Errorf("Expected two origins, got %q", value)
+	}
+	os.Unsetenv("TEST_SLICE")
+
+	value = GetStringSlice("NON_EXISTENT")
+	if value != nil {
+		t.Errorf("Expected nil, got %q", value)
+	}
+}
+
+func TestGetStringSliceOrDefault(t *testing.T) {
+	value := GetStringSliceOrDefault("NON_EXISTENT", []string{"default"})
+	if !reflect.DeepEqual(value, []string{"default"}) {
+		t.Errorf("Expected [default], got %q", value)
+	}
+}
+
+func TestGetIntSliceOrError(t *testing.T) {
+	os.Setenv("TEST_SLICE", "1; 2; 3")
+	value, err := GetIntSliceOrError("TEST_SLICE", WithSeparator(";"))
+	if err != nil {
+		t.Errorf("Expected nil error, got '%s'", err)
+	}
+	if !reflect.DeepEqual(value, []int{1, 2, 3}) {
+		t.Errorf("Expected [1 2 3], got %v", value)
+	}
+	os.Unsetenv("TEST_SLICE")
+
+	_, err = GetIntSliceOrError("NON_EXISTENT")
+	if err == nil {
+		t.Error("Expected error, got nil")
+	}
+
+	os.Setenv("TEST_SLICE_INVALID", "1,abc,3")
+	_, err = GetIntSliceOrError("TEST_SLICE_INVALID")
+	if err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+	if !strings.Contains(err.Error(), "TEST_SLICE_INVALID[1]") {
+		t.Errorf("Expected error to name the element, got '%s'", err)
+	}
+
+	// Skipped empty elements still count towards the position
+	os.Setenv("TEST_SLICE_INVALID", "1,,x")
+	_, err = GetIntSliceOrError("TEST_SLICE_INVALID")
+	if err == nil || !strings.Contains(err.Error(), "TEST_SLICE_INVALID[2]") {
+		t.Errorf("Expected error to name element 2, got '%v'", err)
+	}
+	os.Unsetenv("TEST_SLICE_INVALID")
+}
+
+func TestGetFloatAndBoolSlice(t *testing.T) {
+	reader := New(MapSource{
+		"FLOATS": "0.5, 1.5",
+		"BOOLS":  "yes,no,1",
+	})
+
+	if value := reader.GetFloatSlice("FLOATS"); !reflect.DeepEqual(value, []float64{0.5, 1.5}) {
+		t.Errorf("Expected [0.5 1.5], got %v", value)
+	}
+	if value := reader.GetBoolSlice("BOOLS"); !reflect.DeepEqual(value, []bool{true, false, true}) {
+		t.Errorf("Expected [true false true], got %v", value)
+	}
+	if value := reader.GetBoolSliceOrDefault("FLOATS", []bool{false}); !reflect.DeepEqual(value, []bool{true, true}) {
+		t.Errorf("Expected [true true], got %v", value)
+	}
+}
+
+func TestGetStringSliceOrPanic(t *testing.T) {
+	os.Setenv("TEST_SLICE", "a,b")
+	defer func() {
+		if r := recover(); r != nil {
+			t.Errorf("The code panicked: %v", r)
+		}
+	}()
+	GetStringSliceOrPanic("TEST_SLICE")
+	os.Unsetenv("TEST_SLICE")
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("The code did not panic")
+		}
+	}()
+	GetStringSliceOrPanic("NON_EXISTENT")
+}
@@ -0,0 +1,72 @@
+package env
+
+import "os"
+
+// Source looks up the raw values of environment variables.
+//
+// Implementations report whether the key is present, mirroring os.LookupEnv.
+type Source interface {
+	Lookup(key string) (string, bool)
+}
+
+// SourceFunc adapts an ordinary function to the Source interface.
+type SourceFunc func(key string) (string, bool)
+
+// Lookup calls f(key).
+func (f SourceFunc) Lookup(key string) (string, bool) {
+	return f(key)
+}
+
+// MapSource is a Source backed by an in-memory map.
+type MapSource map[string]string
+
+// Lookup returns the value stored under key.
+func (m MapSource) Lookup(key string) (string, bool) {
+	value, ok := m[key]
+	return value, ok
+}
+
+// OSSource returns a Source that reads the process environment.
+func OSSource() Source {
+	return SourceFunc(os.LookupEnv)
+}
+
+// FileSource parses the .env file at filePath into a Source without
+// modifying the process environment.
+//
+// Parameters:
+//
+//	filePath: The path to the .env file to read.
+//
+// Returns:
+//
+//	The Source, a MapSource holding the parsed values, or a *LoadError if
+//	the file cannot be read or parsed.
+func FileSource(filePath string) (Source, error) {
+	values, err := readEnvFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	return MapSource(values), nil
+}
+
+// StackSource returns a Source that consults each of the given sources in
+// order and returns the first value found.
+func StackSource(sources ...Source) Source {
+	return stackSource(sources)
+}
+
+type stackSource []Source
+
+// Lookup returns the value from the first source that has the key.
+func (s stackSource) Lookup(key string) (string, bool) {
+	for _, source := range s {
+		if source == nil {
+			continue
+		}
+		if value, ok := source.Lookup(key); ok {
+			return value, true
+		}
+	}
+	return "", false
+}
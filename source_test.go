@@ -0,0 +1,86 @@
+package env
+
+import (
+	"os"
+	"testing"
+)
+
+func TestMapSource(t *testing.T) {
+	source := MapSource{"TEST_MAP_KEY": "value"}
+
+	value, ok := source.Lookup("TEST_MAP_KEY")
+	if !ok || value != "value" {
+		t.Errorf("Expected 'value', true, got '%s', %v", value, ok)
+	}
+
+	_, ok = source.Lookup("NON_EXISTENT")
+	if ok {
+		t.Error("Expected false, got true")
+	}
+}
+
+func TestOSSource(t *testing.T) {
+	os.Setenv("TEST_OS_SOURCE", "value")
+	defer os.Unsetenv("TEST_OS_SOURCE")
+
+	value, ok := OSSource().Lookup("TEST_OS_SOURCE")
+	if !ok || value != "value" {
+		t.Errorf("Expected 'value', true, got '%s', %v", value, ok)
+	}
+}
+
+func TestFileSource(t *testing.T) {
+	tempFile, err := os.CreateTemp("", "test.env")
+	if err != nil {
+		t.Fatalf("Error creating temporary file: %v", err)
+	}
+	defer os.Remove(tempFile.Name())
+
+	_, err = tempFile.WriteString("TEST_FILE_SOURCE=file_value\n")
+	tempFile.Close()
+	if err != nil {
+		t.Fatalf("Error writing to temporary file: %v", err)
+	}
+
+	source, err := FileSource(tempFile.Name())
+	if err != nil {
+		t.Fatalf("Expected nil error, got '%s'", err)
+	}
+
+	value, ok := source.Lookup("TEST_FILE_SOURCE")
+	if !ok || value != "file_value" {
+		t.Errorf("Expected 'file_value', true, got '%s', %v", value, ok)
+	}
+
+	if os.Getenv("TEST_FILE_SOURCE") != "" {
+		t.Error("Expected FileSource not to modify the process environment")
+	}
+
+	_, err = FileSource("non_existent.env")
+	if err == nil {
+		t.Error("Expected error, got nil")
+	}
+}
+
+func TestStackSource(t *testing.T) {
+	source := StackSource(
+		MapSource{"TEST_STACK_A": "first"},
+		nil,
+		MapSource{"TEST_STACK_A": "second", "TEST_STACK_B": "second"},
+	)
+
+	value, _ := source.Lookup("TEST_STACK_A")
+	if value != "first" {
+		t.Errorf("Expected 'first', got '%s'", value)
+	}
+
+	value, _ = source.Lookup("TEST_STACK_B")
+	if value != "second" {
+		t.Errorf("Expected 'second', got '%s'", value)
+	}
+
+	_, ok := source.Lookup("NON_EXISTENT")
+	if ok {
+		t.Error("Expected false, got true")
+	}
+}
@@ -2,40 +2,66 @@ package env
 
 import (
 	"fmt"
-	"os"
 )
 
 // GetString retrieves the string value of an environment variable.
 // It returns an empty string if the key is not found.
 func GetString(key string) string {
-	return envProcess(os.Getenv(key))
+	return Default().GetString(key)
 }
 
 // GetStringOrDefault retrieves the string value of an environment variable with a default.
 func GetStringOrDefault(key string, defaultValue string) string {
-	value := os.Getenv(key)
-	if value == "" {
-		return defaultValue
-	}
-	return envProcess(value)
+	return Default().GetStringOrDefault(key, defaultValue)
 }
 
 // GetStringOrError retrieves the string value of an environment variable,
 // returning an error if the key is not found.
 func GetStringOrError(key string) (string, error) {
-	value := os.Getenv(key)
-	if value == "" {
-		return "", fmt.Errorf("environment variable '%s' not found", key)
-	}
-	return envProcess(value), nil
+	return Default().GetStringOrError(key)
 }
 
 // GetStringOrPanic retrieves the string value of an environment variable,
 // panicking if not set.
 func GetStringOrPanic(key string) string {
-	value, err := GetStringOrError(key)
+	return Default().GetStringOrPanic(key)
+}
+
+// GetString retrieves the string value of key from the reader's source.
+// It returns an empty string if the key is not found.
+func (r *Reader) GetString(key string) string {
+	value, err := r.GetStringOrError(key)
+	return orDefault(r, value, err, "")
+}
+
+// GetStringOrDefault retrieves the string value of key with a default.
+func (r *Reader) GetStringOrDefault(key string, defaultValue string) string {
+	value, err := r.GetStringOrError(key)
+	return orDefault(r, value, err, defaultValue)
+}
+
+// GetStringOrError retrieves the string value of key,
+// returning an error if the key is not found.
+func (r *Reader) GetStringOrError(key string) (string, error) {
+	value, ok, err := r.lookup(key)
+	if !ok {
+		return "", notFoundError(key)
+	}
 	if err != nil {
+		return "", err
+	}
+	return value, nil
+}
+
+// GetStringOrPanic retrieves the string value of key,
+// panicking if not set.
+func (r *Reader) GetStringOrPanic(key string) string {
+	value, ok, err := r.lookup(key)
+	if !ok {
 		panic(fmt.Sprintf("Environment variable '%s' is required, but not set.", key))
 	}
+	if err != nil {
+		panic(err)
+	}
 	return value
 }
@@ -0,0 +1,105 @@
+package env
+
+import (
+	"encoding"
+	"errors"
+	"flag"
+	"fmt"
+	"reflect"
+)
+
+// GetText fills target from the value of an environment variable.
+// target must implement encoding.TextUnmarshaler or flag.Value.
+// It reports whether target was filled; target is left untouched if the
+// key is not found or the value cannot be parsed.
+func GetText(key string, target any) bool {
+	return Default().GetText(key, target)
+}
+
+// GetTextOrDefault fills target from the value of an environment variable,
+// or from defaultText if the key is not found.
+func GetTextOrDefault(key string, target any, defaultText string) error {
+	return Default().GetTextOrDefault(key, target, defaultText)
+}
+
+// GetTextOrError fills target from the value of an environment variable,
+// returning an error if the key is not found or the value cannot be parsed.
+func GetTextOrError(key string, target any) error {
+	return Default().GetTextOrError(key, target)
+}
+
+// GetTextOrPanic fills target from the value of an environment variable,
+// panicking if not set or on parsing error.
+func GetTextOrPanic(key string, target any) {
+	Default().GetTextOrPanic(key, target)
+}
+
+// GetText fills target from the value of key.
+// target must implement encoding.TextUnmarshaler or flag.Value.
+// It reports whether target was filled.
+func (r *Reader) GetText(key string, target any) bool {
+	return r.accept(r.GetTextOrError(key, target))
+}
+
+// GetTextOrDefault fills target from the value of key, or from defaultText
+// if the key is not found. An error is returned if the value (or the
+// default) cannot be parsed.
+func (r *Reader) GetTextOrDefault(key string, target any, defaultText string) error {
+	err := r.GetTextOrError(key, target)
+	if errors.Is(err, ErrNotFound) {
+		return unmarshalText(key, defaultText, target)
+	}
+	return err
+}
+
+// GetTextOrError fills target from the value of key,
+// returning an error if the key is not found or the value cannot be parsed.
+//
+// The value is processed (base64:, obfuscated:) before being passed to
+// the target's UnmarshalText or Set method.
+func (r *Reader) GetTextOrError(key string, target any) error {
+	_, err := readValue(r, key, func(key string, valueStr string) (any, error) {
+		return target, unmarshalText(key, valueStr, target)
+	})
+	return err
+}
+
+// GetTextOrPanic fills target from the value of key,
+// panicking if not set or on parsing error.
+func (r *Reader) GetTextOrPanic(key string, target any) {
+	if err := r.GetTextOrError(key, target); err != nil {
+		panic(err)
+	}
+}
+
+// unmarshalText passes valueStr to target's UnmarshalText or Set method.
+func unmarshalText(key string, valueStr string, target any) error {
+	var err error
+
+	switch t := target.(type) {
+	case encoding.TextUnmarshaler:
+		err = t.UnmarshalText([]byte(valueStr))
+	case flag.Value:
+		err = t.Set(valueStr)
+	default:
+		return fmt.Errorf("environment variable '%s': target of type %T implements neither encoding.TextUnmarshaler nor flag.Value", key, target)
+	}
+
+	if err != nil {
+		typ := reflect.TypeOf(target)
+		if typ.Kind() == reflect.Pointer {
+			typ = typ.Elem()
+		}
+		return invalidError(key, valueStr, typ.String(), err)
+	}
+
+	return nil
+}
+
+// isTextTarget reports whether a pointer to a value of typ can be filled
+// by unmarshalText.
+func isTextTarget(typ reflect.Type) bool {
+	pointerType := reflect.PointerTo(typ)
+	return pointerType.Implements(reflect.TypeFor[encoding.TextUnmarshaler]()) ||
+		pointerType.Implements(reflect.TypeFor[flag.Value]())
+}
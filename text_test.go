@@ -0,0 +1,165 @@
+package env
+
+import (
+	"errors"
+	"net"
+	"os"
+	"strings"
+	"testing"
+)
+
+type testLevel int
+
+func (l *testLevel) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "debug":
+		*l = 0
+	case "info":
+		*l = 1
+	case "error":
+		*l = 2
+	default:
+		return errors.New("unknown level")
+	}
+	return nil
+}
+
+type testList []string
+
+func (l *testList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *testList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// testMode is a flag.Value with value receivers.
+type testMode map[string]bool
+
+func (m testMode) String() string {
+	return ""
+}
+
+func (m testMode) Set(value string) error {
+	if value != "on" && value != "off" {
+		return errors.New("unknown mode")
+	}
+	m[value] = true
+	return nil
+}
+
+func TestGetText(t *testing.T) {
+	os.Setenv("TEST_TEXT_LEVEL", "error")
+	defer os.Unsetenv("TEST_TEXT_LEVEL")
+
+	var level testLevel
+	if !GetText("TEST_TEXT_LEVEL", &level) {
+		t.Error("Expected true, got false")
+	}
+	if level != 2 {
+		t.Errorf("Expected 2, got %d", level)
+	}
+
+	level = 1
+	if GetText("NON_EXISTENT", &level) {
+		t.Error("Expected false, got true")
+	}
+	if level != 1 {
+		t.Errorf("Expected level to be untouched, got %d", level)
+	}
+}
+
+func TestGetTextOrDefault(t *testing.T) {
+	var level testLevel
+	if err := GetTextOrDefault("NON_EXISTENT", &level, "info"); err != nil {
+		t.Errorf("Expected nil error, got '%s'", err)
+	}
+	if level != 1 {
+		t.Errorf("Expected 1, got %d", level)
+	}
+}
+
+func TestGetTextOrError(t *testing.T) {
+	os.Setenv("TEST_TEXT_IP", "base64:MTAuMC4wLjE=")
+	os.Setenv("TEST_TEXT_INVALID", "verbose")
+	defer os.Unsetenv("TEST_TEXT_IP")
+	defer os.Unsetenv("TEST_TEXT_INVALID")
+
+	var ip net.IP
+	if err := GetTextOrError("TEST_TEXT_IP", &ip); err != nil {
+		t.Errorf("Expected nil error, got '%s'", err)
+	}
+	if ip.String() != "10.0.0.1" {
+		t.Errorf("Expected '10.0.0.1', got '%s'", ip)
+	}
+
+	var level testLevel
+	err := GetTextOrError("TEST_TEXT_INVALID", &level)
+	if err == nil || !strings.Contains(err.Error(), "unknown level") {
+		t.Errorf("Expected parse error, got '%v'", err)
+	}
+
+	err = GetTextOrError("NON_EXISTENT", &level)
+	if err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("Expected not found error, got '%v'", err)
+	}
+
+	var list testList
+	if err := New(MapSource{"LIST": "a"}).GetTextOrError("LIST", &list); err != nil || list.String() != "a" {
+		t.Errorf("Expected flag.Value to be set to 'a', got '%s' (%v)", list.String(), err)
+	}
+
+	var unsupported int
+	if err := GetTextOrError("TEST_TEXT_IP", &unsupported); err == nil {
+		t.Error("Expected error for unsupported target, got nil")
+	}
+}
+
+func TestGetTextOrPanic(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("The code did not panic")
+		}
+	}()
+	var level testLevel
+	GetTextOrPanic("NON_EXISTENT", &level)
+}
+
+func TestTextUnmarshalerWithGetAndBind(t *testing.T) {
+	os.Setenv("TEST_TEXT_LEVEL", "info")
+	defer os.Unsetenv("TEST_TEXT_LEVEL")
+
+	level, err := GetOrError[testLevel]("TEST_TEXT_LEVEL")
+	if err != nil || level != 1 {
+		t.Errorf("Expected 1, got %d (%v)", level, err)
+	}
+
+	type config struct {
+		Level testLevel `env:"TEST_TEXT_LEVEL"`
+	}
+	cfg := config{}
+	if err := Bind(&cfg); err != nil || cfg.Level != 1 {
+		t.Errorf("Expected 1, got %d (%v)", cfg.Level, err)
+	}
+}
+
+func TestGetTextValueReceiver(t *testing.T) {
+	mode := testMode{}
+	reader := New(MapSource{"MODE": "on", "BAD_MODE": "loud"})
+
+	if err := reader.GetTextOrError("MODE", mode); err != nil || !mode["on"] {
+		t.Errorf("Expected mode 'on' to be set, got %v (%v)", mode, err)
+	}
+
+	err := reader.GetTextOrError("BAD_MODE", mode)
+
+	var varErr *VarError
+	if !errors.As(err, &varErr) {
+		t.Fatalf("Expected *VarError, got %v", err)
+	}
+	if varErr.Type != "env.testMode" {
+		t.Errorf("Expected type 'env.testMode', got '%s'", varErr.Type)
+	}
+}
@@ -0,0 +1,311 @@
+package env
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// GetUint retrieves the uint value of an environment variable.
+// It returns 0 if the key is not found or the value is not a valid uint.
+func GetUint(key string) uint {
+	return Default().GetUint(key)
+}
+
+// GetUintOrDefault retrieves the uint value of an environment variable with a default.
+func GetUintOrDefault(key string, defaultValue uint) uint {
+	return Default().GetUintOrDefault(key, defaultValue)
+}
+
+// GetUintOrError retrieves the uint value of an environment variable,
+// returning an error if the key is not found or the value is not a valid uint.
+func GetUintOrError(key string) (uint, error) {
+	return Default().GetUintOrError(key)
+}
+
+// GetUintOrPanic retrieves the uint value of an environment variable,
+// panicking if not set or on parsing error.
+func GetUintOrPanic(key string) uint {
+	return Default().GetUintOrPanic(key)
+}
+
+// GetUint8 retrieves the uint8 value of an environment variable.
+// It returns 0 if the key is not found or the value is not a valid uint8.
+func GetUint8(key string) uint8 {
+	return Default().GetUint8(key)
+}
+
+// GetUint8OrDefault retrieves the uint8 value of an environment variable with a default.
+func GetUint8OrDefault(key string, defaultValue uint8) uint8 {
+	return Default().GetUint8OrDefault(key, defaultValue)
+}
+
+// GetUint8OrError retrieves the uint8 value of an environment variable,
+// returning an error if the key is not found or the value is not a valid uint8.
+func GetUint8OrError(key string) (uint8, error) {
+	return Default().GetUint8OrError(key)
+}
+
+// GetUint8OrPanic retrieves the uint8 value of an environment variable,
+// panicking if not set or on parsing error.
+func GetUint8OrPanic(key string) uint8 {
+	return Default().GetUint8OrPanic(key)
+}
+
+// GetUint16 retrieves the uint16 value of an environment variable.
+// It returns 0 if the key is not found or the value is not a valid uint16.
+func GetUint16(key string) uint16 {
+	return Default().GetUint16(key)
+}
+
+// GetUint16OrDefault retrieves the uint16 value of an environment variable with a default.
+func GetUint16OrDefault(key string, defaultValue uint16) uint16 {
+	return Default().GetUint16OrDefault(key, defaultValue)
+}
+
+// GetUint16OrError retrieves the uint16 value of an environment variable,
+// returning an error if the key is not found or the value is not a valid uint16.
+func GetUint16OrError(key string) (uint16, error) {
+	return Default().GetUint16OrError(key)
+}
+
+// GetUint16OrPanic retrieves the uint16 value of an environment variable,
+// panicking if not set or on parsing error.
+func GetUint16OrPanic(key string) uint16 {
+	return Default().GetUint16OrPanic(key)
+}
+
+// GetUint32 retrieves the uint32 value of an environment variable.
+// It returns 0 if the key is not found or the value is not a valid uint32.
+func GetUint32(key string) uint32 {
+	return Default().GetUint32(key)
+}
+
+// GetUint32OrDefault retrieves the uint32 value of an environment variable with a default.
+func GetUint32OrDefault(key string, defaultValue uint32) uint32 {
+	return Default().GetUint32OrDefault(key, defaultValue)
+}
+
+// GetUint32OrError retrieves the uint32 value of an environment variable,
+// returning an error if the key is not found or the value is not a valid uint32.
+func GetUint32OrError(key string) (uint32, error) {
+	return Default().GetUint32OrError(key)
+}
+
+// GetUint32OrPanic retrieves the uint32 value of an environment variable,
+// panicking if not set or on parsing error.
+func GetUint32OrPanic(key string) uint32 {
+	return Default().GetUint32OrPanic(key)
+}
+
+// GetUint64 retrieves the uint64 value of an environment variable.
+// It returns 0 if the key is not found or the value is not a valid uint64.
+func GetUint64(key string) uint64 {
+	return Default().GetUint64(key)
+}
+
+// GetUint64OrDefault retrieves the uint64 value of an environment variable with a default.
+func GetUint64OrDefault(key string, defaultValue uint64) uint64 {
+	return Default().GetUint64OrDefault(key, defaultValue)
+}
+
+// GetUint64OrError retrieves the uint64 value of an environment variable,
+// returning an error if the key is not found or the value is not a valid uint64.
+func GetUint64OrError(key string) (uint64, error) {
+	return Default().GetUint64OrError(key)
+}
+
+// GetUint64OrPanic retrieves the uint64 value of an environment variable,
+// panicking if not set or on parsing error.
+func GetUint64OrPanic(key string) uint64 {
+	return Default().GetUint64OrPanic(key)
+}
+
+// GetUint retrieves the uint value of key.
+// It returns 0 if the key is not found or the value is not a valid uint.
+func (r *Reader) GetUint(key string) uint {
+	value, err := r.GetUintOrError(key)
+	return orDefault(r, value, err, 0)
+}
+
+// GetUintOrDefault retrieves the uint value of key with a default.
+func (r *Reader) GetUintOrDefault(key string, defaultValue uint) uint {
+	value, err := r.GetUintOrError(key)
+	return orDefault(r, value, err, defaultValue)
+}
+
+// GetUintOrError retrieves the uint value of key,
+// returning an error if the key is not found or the value is not a valid uint.
+// A value that does not fit in uint produces an error wrapping ErrOutOfRange.
+func (r *Reader) GetUintOrError(key string) (uint, error) {
+	return readValue(r, key, parseUint)
+}
+
+// GetUintOrPanic retrieves the uint value of key,
+// panicking if not set or on parsing error.
+func (r *Reader) GetUintOrPanic(key string) uint {
+	value, err := r.GetUintOrError(key)
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
+// GetUint8 retrieves the uint8 value of key.
+// It returns 0 if the key is not found or the value is not a valid uint8.
+func (r *Reader) GetUint8(key string) uint8 {
+	value, err := r.GetUint8OrError(key)
+	return orDefault(r, value, err, 0)
+}
+
+// GetUint8OrDefault retrieves the uint8 value of key with a default.
+func (r *Reader) GetUint8OrDefault(key string, defaultValue uint8) uint8 {
+	value, err := r.GetUint8OrError(key)
+	return orDefault(r, value, err, defaultValue)
+}
+
+// GetUint8OrError retrieves the uint8 value of key,
+// returning an error if the key is not found or the value is not a valid uint8.
+// A value that does not fit in uint8 produces an error wrapping ErrOutOfRange.
+func (r *Reader) GetUint8OrError(key string) (uint8, error) {
+	return readValue(r, key, parseUint8)
+}
+
+// GetUint8OrPanic retrieves the uint8 value of key,
+// panicking if not set or on parsing error.
+func (r *Reader) GetUint8OrPanic(key string) uint8 {
+	value, err := r.GetUint8OrError(key)
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
+// GetUint16 retrieves the uint16 value of key.
+// It returns 0 if the key is not found or the value is not a valid uint16.
+func (r *Reader) GetUint16(key string) uint16 {
+	value, err := r.GetUint16OrError(key)
+	return orDefault(r, value, err, 0)
+}
+
+// GetUint16OrDefault retrieves the uint16 value of key with a default.
+func (r *Reader) GetUint16OrDefault(key string, defaultValue uint16) uint16 {
+	value, err := r.GetUint16OrError(key)
+	return orDefault(r, value, err, defaultValue)
+}
+
+// GetUint16OrError retrieves the uint16 value of key,
+// returning an error if the key is not found or the value is not a valid uint16.
+// A value that does not fit in uint16 produces an error wrapping ErrOutOfRange.
+func (r *Reader) GetUint16OrError(key string) (uint16, error) {
+	return readValue(r, key, parseUint16)
+}
+
+// GetUint16OrPanic retrieves the uint16 value of key,
+// panicking if not set or on parsing error.
+func (r *Reader) GetUint16OrPanic(key string) uint16 {
+	value, err := r.GetUint16OrError(key)
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
+// GetUint32 retrieves the uint32 value of key.
+// It returns 0 if the key is not found or the value is not a valid uint32.
+func (r *Reader) GetUint32(key string) uint32 {
+	value, err := r.GetUint32OrError(key)
+	return orDefault(r, value, err, 0)
+}
+
+// GetUint32OrDefault retrieves the uint32 value of key with a default.
+func (r *Reader) GetUint32OrDefault(key string, defaultValue uint32) uint32 {
+	value, err := r.GetUint32OrError(key)
+	return orDefault(r, value, err, defaultValue)
+}
+
+// GetUint32OrError retrieves the uint32 value of key,
+// returning an error if the key is not found or the value is not a valid uint32.
+// A value that does not fit in uint32 produces an error wrapping ErrOutOfRange.
+func (r *Reader) GetUint32OrError(key string) (uint32, error) {
+	return readValue(r, key, parseUint32)
+}
+
+// GetUint32OrPanic retrieves the uint32 value of key,
+// panicking if not set or on parsing error.
+func (r *Reader) GetUint32OrPanic(key string) uint32 {
+	value, err := r.GetUint32OrError(key)
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
+// GetUint64 retrieves the uint64 value of key.
+// It returns 0 if the key is not found or the value is not a valid uint64.
+func (r *Reader) GetUint64(key string) uint64 {
+	value, err := r.GetUint64OrError(key)
+	return orDefault(r, value, err, 0)
+}
+
+// GetUint64OrDefault retrieves the uint64 value of key with a default.
+func (r *Reader) GetUint64OrDefault(key string, defaultValue uint64) uint64 {
+	value, err := r.GetUint64OrError(key)
+	return orDefault(r, value, err, defaultValue)
+}
+
+// GetUint64OrError retrieves the uint64 value of key,
+// returning an error if the key is not found or the value is not a valid uint64.
+// A value that does not fit in uint64 produces an error wrapping ErrOutOfRange.
+func (r *Reader) GetUint64OrError(key string) (uint64, error) {
+	return readValue(r, key, parseUint64)
+}
+
+// GetUint64OrPanic retrieves the uint64 value of key,
+// panicking if not set or on parsing error.
+func (r *Reader) GetUint64OrPanic(key string) uint64 {
+	value, err := r.GetUint64OrError(key)
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
+// parseUint parses a uint value read from the environment variable key.
+func parseUint(key string, valueStr string) (uint, error) {
+	return parseUnsigned[uint](key, valueStr, strconv.IntSize, "uint")
+}
+
+// parseUint8 parses a uint8 value read from the environment variable key.
+func parseUint8(key string, valueStr string) (uint8, error) {
+	return parseUnsigned[uint8](key, valueStr, 8, "uint8")
+}
+
+// parseUint16 parses a uint16 value read from the environment variable key.
+func parseUint16(key string, valueStr string) (uint16, error) {
+	return parseUnsigned[uint16](key, valueStr, 16, "uint16")
+}
+
+// parseUint32 parses a uint32 value read from the environment variable key.
+func parseUint32(key string, valueStr string) (uint32, error) {
+	return parseUnsigned[uint32](key, valueStr, 32, "uint32")
+}
+
+// parseUint64 parses a uint64 value read from the environment variable key.
+func parseUint64(key string, valueStr string) (uint64, error) {
+	return parseUnsigned[uint64](key, valueStr, 64, "uint64")
+}
+
+// parseUnsigned parses an unsigned integer of the given bit size, reporting
+// values that do not fit as ErrOutOfRange.
+func parseUnsigned[T uint | uint8 | uint16 | uint32 | uint64](key string, valueStr string, bitSize int, typeName string) (T, error) {
+	value, err := strconv.ParseUint(valueStr, 10, bitSize)
+	if err != nil {
+		// A valid negative integer is out of range rather than malformed
+		if _, errSigned := strconv.ParseInt(valueStr, 10, 64); strings.HasPrefix(valueStr, "-") && (errSigned == nil || errors.Is(errSigned, strconv.ErrRange)) {
+			err = strconv.ErrRange
+		}
+		return 0, integerError(key, valueStr, typeName, err)
+	}
+	return T(value), nil
+}
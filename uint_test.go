@@ -0,0 +1,79 @@
+package env
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestGetUint16(t *testing.T) {
+	os.Setenv("TEST_UINT16", "8080")
+	value := GetUint16("TEST_UINT16")
+	if value != 8080 {
+		t.Errorf("Expected 8080, got %d", value)
+	}
+	os.Unsetenv("TEST_UINT16")
+
+	os.Setenv("TEST_UINT16_OVER", "70000")
+	value = GetUint16("TEST_UINT16_OVER")
+	if value != 0 {
+		t.Errorf("Expected 0, got %d", value)
+	}
+	os.Unsetenv("TEST_UINT16_OVER")
+}
+
+func TestGetUintOrDefault(t *testing.T) {
+	value := GetUintOrDefault("NON_EXISTENT", 42)
+	if value != 42 {
+		t.Errorf("Expected 42, got %d", value)
+	}
+}
+
+func TestGetUnsignedOrError(t *testing.T) {
+	reader := New(MapSource{
+		"UINT8_MAX":    "255",
+		"UINT8_OVER":   "256",
+		"UINT32_MAX":   "4294967295",
+		"UINT32_OVER":  "4294967296",
+		"UINT64_MAX":   "18446744073709551615",
+		"UINT64_OVER":  "18446744073709551616",
+		"NEGATIVE":     "-1",
+		"NOT_A_NUMBER": "abc",
+	})
+
+	if value, err := reader.GetUint8OrError("UINT8_MAX"); err != nil || value != 255 {
+		t.Errorf("Expected 255, got %d (%v)", value, err)
+	}
+	if value, err := reader.GetUint32OrError("UINT32_MAX"); err != nil || value != 4294967295 {
+		t.Errorf("Expected 4294967295, got %d (%v)", value, err)
+	}
+	if value, err := reader.GetUint64OrError("UINT64_MAX"); err != nil || value != 18446744073709551615 {
+		t.Errorf("Expected 18446744073709551615, got %d (%v)", value, err)
+	}
+
+	overflows := []func() error{
+		func() error { _, err := reader.GetUint8OrError("UINT8_OVER"); return err },
+		func() error { _, err := reader.GetUint32OrError("UINT32_OVER"); return err },
+		func() error { _, err := reader.GetUint64OrError("UINT64_OVER"); return err },
+		func() error { _, err := reader.GetUintOrError("NEGATIVE"); return err },
+	}
+	for i, overflow := range overflows {
+		if err := overflow(); !errors.Is(err, ErrOutOfRange) {
+			t.Errorf("case %d: expected ErrOutOfRange, got %v", i, err)
+		}
+	}
+
+	_, err := reader.GetUint64OrError("NOT_A_NUMBER")
+	if err == nil || errors.Is(err, ErrOutOfRange) {
+		t.Errorf("Expected a non-range error, got %v", err)
+	}
+}
+
+func TestGetUint64OrPanic(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("The code did not panic")
+		}
+	}()
+	GetUint64OrPanic("NON_EXISTENT")
+}
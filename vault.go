@@ -0,0 +1,317 @@
+package env
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dracory/envenc"
+)
+
+// VaultInit creates an empty vault file, readable only by its owner.
+//
+// The options follow the conventions of LoadVaultWithOptions, except that
+// VaultFilePath must not exist yet and VaultContent cannot be used.
+//
+// Parameters:
+//
+//	options: The vault file to create and its password.
+//
+// Returns:
+//
+//	An error if the options are invalid or the file cannot be written. The
+//	error matches fs.ErrExist if the file already exists.
+func VaultInit(options VaultOptions) error {
+	if options.Password == "" && len(options.PasswordSources) == 0 {
+		return errors.New("password is required")
+	}
+
+	if err := checkVaultWritable(options); err != nil {
+		return err
+	}
+
+	if fileExists(options.VaultFilePath) {
+		return &sentinelError{message: "Vault file already exists: " + options.VaultFilePath, sentinel: fs.ErrExist}
+	}
+
+	password, err := resolveVaultPassword(options)
+	if err != nil {
+		return err
+	}
+
+	// envenc.Init generates the vault identifier but writes the file with
+	// mode 0644, so the vault is created in a private directory and then
+	// written to its path with mode 0600
+	dir, err := os.MkdirTemp(filepath.Dir(options.VaultFilePath), "."+filepath.Base(options.VaultFilePath)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	initial := filepath.Join(dir, filepath.Base(options.VaultFilePath))
+	if err := envenc.Init(initial, password); err != nil {
+		return err
+	}
+
+	keys, err := envenc.KeyListFromFile(initial, password)
+	if err != nil {
+		return err
+	}
+
+	return writeVaultFile(options.VaultFilePath, password, keys)
+}
+
+// VaultList returns the variables stored in a vault, without the internal
+// vault identifier.
+//
+// Parameters:
+//
+//	options: The vault to read and its password. Override is ignored.
+//
+// Returns:
+//
+//	The variables and an error if the vault cannot be read. The error
+//	matches ErrNotFound if the vault file does not exist.
+func VaultList(options VaultOptions) (map[string]string, error) {
+	return readVault(options)
+}
+
+// VaultSet sets a variable in a vault file, replacing its current value.
+//
+// Parameters:
+//
+//	options: The vault file to modify and its password.
+//	key: The variable name.
+//	value: The value to store.
+//
+// Returns:
+//
+//	An error if the options or the key are invalid, or if the vault cannot
+//	be read or written. Invalid keys match ErrInvalid.
+func VaultSet(options VaultOptions, key string, value string) error {
+	return VaultApply(options, map[string]string{key: value})
+}
+
+// VaultApply sets several variables in a vault file at once. The keys are
+// checked before anything is written, and the vault is written once, so
+// either every value is stored or none is.
+//
+// Parameters:
+//
+//	options: The vault file to modify and its password.
+//	values: The variables to set.
+//
+// Returns:
+//
+//	An error if the options or a key are invalid, or if the vault cannot be
+//	read or written. Invalid keys match ErrInvalid.
+func VaultApply(options VaultOptions, values map[string]string) error {
+	for _, key := range sortedKeys(values) {
+		if err := checkVaultKey(key); err != nil {
+			return err
+		}
+	}
+
+	keys, password, err := openVaultFile(options)
+	if err != nil {
+		return err
+	}
+
+	for key, value := range values {
+		keys[key] = value
+	}
+
+	return writeVaultFile(options.VaultFilePath, password, keys)
+}
+
+// VaultDelete removes a variable from a vault file.
+//
+// Parameters:
+//
+//	options: The vault file to modify and its password.
+//	key: The variable name.
+//
+// Returns:
+//
+//	An error if the options or the key are invalid, or if the vault cannot
+//	be read or written. The error matches ErrNotFound if the vault does not
+//	hold the key.
+func VaultDelete(options VaultOptions, key string) error {
+	if err := checkVaultKey(key); err != nil {
+		return err
+	}
+
+	keys, password, err := openVaultFile(options)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := keys[key]; !ok {
+		return &sentinelError{message: fmt.Sprintf("key '%s' not found in %s", key, options.VaultFilePath), sentinel: ErrNotFound}
+	}
+
+	delete(keys, key)
+
+	return writeVaultFile(options.VaultFilePath, password, keys)
+}
+
+// RotateVaultPassword re-encrypts a vault file with a new password.
+//
+// The vault is written to a temporary file next to it, synced to disk and
+// checked to decrypt with newPassword to the same keys before it replaces
+// the original with a rename. On any failure the original is left
+// untouched.
+//
+// Parameters:
+//
+//	path: The vault file.
+//	oldPassword: The current password.
+//	newPassword: The password to encrypt the vault with. Surrounding
+//	whitespace is ignored, as for VaultOptions.Password.
+//
+// Returns:
+//
+//	An error if the vault cannot be decrypted with oldPassword or the new
+//	vault cannot be written or verified. The error matches ErrNotFound if
+//	the vault file does not exist.
+func RotateVaultPassword(path string, oldPassword string, newPassword string) error {
+	newPassword = vaultPassword(newPassword)
+	if newPassword == "" {
+		return errors.New("new password is required")
+	}
+
+	keys, _, err := openVaultFile(VaultOptions{Password: oldPassword, VaultFilePath: path})
+	if err != nil {
+		return err
+	}
+
+	return writeVaultFile(path, newPassword, keys)
+}
+
+// checkVaultWritable rejects options that do not name a vault file.
+func checkVaultWritable(options VaultOptions) error {
+	if options.VaultContent != "" {
+		return errors.New("vault content cannot be modified, vault file path is required")
+	}
+
+	if options.VaultFilePath == "" {
+		return errors.New("vault file path is required")
+	}
+
+	return nil
+}
+
+// checkVaultKey rejects names that cannot be stored in a vault.
+func checkVaultKey(key string) error {
+	switch {
+	case key == "":
+		return &sentinelError{message: "key cannot be empty", sentinel: ErrInvalid}
+	case key == vaultIDKey:
+		return &sentinelError{message: fmt.Sprintf("key '%s' is reserved by the vault format", vaultIDKey), sentinel: ErrInvalid}
+	case strings.ContainsAny(key, "= \t\r\n"):
+		return &sentinelError{message: fmt.Sprintf("key '%s' cannot contain '=' or whitespace", key), sentinel: ErrInvalid}
+	}
+	return nil
+}
+
+// openVaultFile validates the options of a write and decrypts the vault
+// file. The returned keys include the internal vault identifier, so that
+// writing them back preserves it.
+func openVaultFile(options VaultOptions) (map[string]string, string, error) {
+	if err := checkVaultWritable(options); err != nil {
+		return nil, "", err
+	}
+
+	if err := checkVaultOptions(options); err != nil {
+		return nil, "", err
+	}
+
+	password, err := resolveVaultPassword(options)
+	if err != nil {
+		return nil, "", err
+	}
+
+	keys, err := envenc.KeyListFromFile(options.VaultFilePath, password)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return keys, password, nil
+}
+
+// writeVaultFile encrypts keys, in the JSON object format of envenc, and
+// atomically replaces path with them: the vault is written to a temporary
+// file in the same directory, synced, verified to decrypt to keys and then
+// renamed over path.
+func writeVaultFile(path string, password string, keys map[string]string) error {
+	content, err := json.Marshal(keys)
+	if err != nil {
+		return err
+	}
+
+	encrypted, err := envenc.Encrypt(string(content), password)
+	if err != nil {
+		return err
+	}
+
+	mode := fs.FileMode(0o600)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+
+	temp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(temp.Name())
+
+	if err := writeAndSync(temp, []byte(encrypted), mode); err != nil {
+		return err
+	}
+
+	written, err := envenc.KeyListFromFile(temp.Name(), password)
+	if err != nil {
+		return fmt.Errorf("vault verification failed: %w", err)
+	}
+	if !maps.Equal(written, keys) {
+		return errors.New("vault verification failed: the written vault does not hold the expected keys")
+	}
+
+	if err := os.Rename(temp.Name(), path); err != nil {
+		return err
+	}
+
+	// Persist the rename; directories cannot be synced on every platform
+	if dir, err := os.Open(filepath.Dir(path)); err == nil {
+		_ = dir.Sync()
+		_ = dir.Close()
+	}
+
+	return nil
+}
+
+// writeAndSync writes data to file, sets its mode, syncs it to disk and
+// closes it.
+func writeAndSync(file *os.File, data []byte, mode fs.FileMode) error {
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+
+	if err := file.Chmod(mode); err != nil {
+		file.Close()
+		return err
+	}
+
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
@@ -0,0 +1,212 @@
+package env
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/dracory/envenc"
+)
+
+func TestVaultWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env.vault")
+	options := VaultOptions{Password: "secret", VaultFilePath: path}
+
+	if err := VaultInit(options); err != nil {
+		t.Fatalf("Expected nil error, got '%s'", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("Expected a new vault to have mode 0600, got %v", info.Mode().Perm())
+	}
+	if entries, _ := os.ReadDir(filepath.Dir(path)); len(entries) != 1 {
+		t.Errorf("Expected only the vault in its directory, got %d entries", len(entries))
+	}
+
+	if err := VaultInit(options); !errors.Is(err, fs.ErrExist) {
+		t.Errorf("Expected fs.ErrExist for an existing vault, got %v", err)
+	}
+
+	if err := VaultSet(options, "DB_HOST", "localhost"); err != nil {
+		t.Fatalf("Expected nil error, got '%s'", err)
+	}
+
+	if err := VaultApply(options, map[string]string{"DB_PORT": "5432", "DB_HOST": "db"}); err != nil {
+		t.Fatalf("Expected nil error, got '%s'", err)
+	}
+
+	keys, err := VaultList(options)
+	if err != nil {
+		t.Fatalf("Expected nil error, got '%s'", err)
+	}
+
+	expected := map[string]string{"DB_HOST": "db", "DB_PORT": "5432"}
+	if !reflect.DeepEqual(keys, expected) {
+		t.Errorf("Expected %v, got %v", expected, keys)
+	}
+
+	if err := VaultDelete(options, "DB_PORT"); err != nil {
+		t.Fatalf("Expected nil error, got '%s'", err)
+	}
+
+	if err := VaultDelete(options, "DB_PORT"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for a missing key, got %v", err)
+	}
+
+	// The vault stays readable by envenc, with its identifier preserved
+	raw, err := envenc.KeyListFromFile(path, "secret")
+	if err != nil {
+		t.Fatalf("Expected envenc to read the vault, got '%s'", err)
+	}
+	if raw[vaultIDKey] == "" || raw["DB_HOST"] != "db" {
+		t.Errorf("Expected the vault id and DB_HOST, got %v", raw)
+	}
+	if _, ok := raw["DB_PORT"]; ok {
+		t.Error("Expected DB_PORT to be deleted")
+	}
+}
+
+func TestVaultApplyInvalidKey(t *testing.T) {
+	path := createTestVault(t, "secret", map[string]string{"KEEP": "value"})
+	options := VaultOptions{Password: "secret", VaultFilePath: path}
+
+	for _, key := range []string{"", vaultIDKey, "A=B", "A B"} {
+		err := VaultApply(options, map[string]string{"VALID": "1", key: "value"})
+		if !errors.Is(err, ErrInvalid) {
+			t.Errorf("%q: expected ErrInvalid, got %v", key, err)
+		}
+	}
+
+	keys, _ := VaultList(options)
+	if !reflect.DeepEqual(keys, map[string]string{"KEEP": "value"}) {
+		t.Errorf("Expected the vault to be unchanged, got %v", keys)
+	}
+}
+
+func TestVaultWriteOptions(t *testing.T) {
+	path := createTestVault(t, "secret", nil)
+	missing := filepath.Join(t.TempDir(), "missing.vault")
+
+	tests := []struct {
+		name    string
+		options VaultOptions
+		message string
+	}{
+		{"no password", VaultOptions{VaultFilePath: path}, "password is required"},
+		{"no path", VaultOptions{Password: "secret"}, "vault file path is required"},
+		{"content", VaultOptions{Password: "secret", VaultContent: "content"}, "vault content cannot be modified, vault file path is required"},
+		{"missing file", VaultOptions{Password: "secret", VaultFilePath: missing}, "Vault file not found: " + missing},
+	}
+
+	for _, tt := range tests {
+		err := VaultSet(tt.options, "KEY", "value")
+		if err == nil || err.Error() != tt.message {
+			t.Errorf("%s: expected '%s', got %v", tt.name, tt.message, err)
+		}
+	}
+
+	if err := VaultSet(VaultOptions{Password: "wrong", VaultFilePath: path}, "KEY", "value"); err == nil {
+		t.Error("Expected error for a wrong password, got nil")
+	}
+
+	if err := VaultInit(VaultOptions{VaultFilePath: missing}); err == nil || err.Error() != "password is required" {
+		t.Errorf("Expected 'password is required', got %v", err)
+	}
+}
+
+func TestRotateVaultPassword(t *testing.T) {
+	path := createTestVault(t, "old", map[string]string{"DB_HOST": "localhost", "DB_PASSWORD": "s3cr3t"})
+	os.Chmod(path, 0o640)
+
+	before, err := envenc.KeyListFromFile(path, "old")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := RotateVaultPassword(path, "old", "new"); err != nil {
+		t.Fatalf("Expected nil error, got '%s'", err)
+	}
+
+	if _, err := envenc.KeyListFromFile(path, "old"); err == nil {
+		t.Error("Expected the old password to be rejected")
+	}
+
+	after, err := envenc.KeyListFromFile(path, "new")
+	if err != nil {
+		t.Fatalf("Expected the new password to decrypt the vault, got '%s'", err)
+	}
+	if !reflect.DeepEqual(before, after) {
+		t.Errorf("Expected %v, got %v", before, after)
+	}
+
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0o640 {
+		t.Errorf("Expected the file mode to be kept, got %v", info.Mode().Perm())
+	}
+
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 1 {
+		t.Errorf("Expected no temporary files to be left, got %d entries", len(entries))
+	}
+}
+
+func TestRotateVaultPasswordFailure(t *testing.T) {
+	path := createTestVault(t, "old", map[string]string{"KEY": "value"})
+
+	original, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := RotateVaultPassword(path, "wrong", "new"); err == nil {
+		t.Error("Expected error for a wrong password, got nil")
+	}
+
+	if err := RotateVaultPassword(path, "old", ""); err == nil || err.Error() != "new password is required" {
+		t.Errorf("Expected 'new password is required', got %v", err)
+	}
+
+	current, _ := os.ReadFile(path)
+	if string(current) != string(original) {
+		t.Error("Expected the vault to be untouched")
+	}
+
+	if err := RotateVaultPassword(filepath.Join(t.TempDir(), "missing"), "old", "new"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestVaultPasswordWhitespace(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env.vault")
+
+	if err := VaultInit(VaultOptions{Password: " pw\n", VaultFilePath: path}); err != nil {
+		t.Fatalf("Expected nil error, got '%s'", err)
+	}
+	if err := VaultSet(VaultOptions{Password: "pw", VaultFilePath: path}, "KEY", "value"); err != nil {
+		t.Fatalf("Expected the trimmed password to open the vault, got '%s'", err)
+	}
+
+	if err := RotateVaultPassword(path, "pw", " new pw "); err != nil {
+		t.Fatalf("Expected nil error, got '%s'", err)
+	}
+
+	for _, password := range []string{"new pw", " new pw ", "new pw\n"} {
+		keys, err := VaultList(VaultOptions{Password: password, VaultFilePath: path})
+		if err != nil || keys["KEY"] != "value" {
+			t.Errorf("%q: expected the vault to open, got %v %v", password, keys, err)
+		}
+	}
+
+	if err := RotateVaultPassword(path, "new pw", "  "); err == nil || err.Error() != "new password is required" {
+		t.Errorf("Expected 'new password is required', got %v", err)
+	}
+	if err := VaultSet(VaultOptions{Password: " ", VaultFilePath: path}, "KEY", "value"); err == nil || err.Error() != "password is required" {
+		t.Errorf("Expected 'password is required', got %v", err)
+	}
+}